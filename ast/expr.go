@@ -6,10 +6,18 @@ type ExprVisitor interface {
 VisitAssignExpr(expr Assign) interface{}
 VisitBinaryExpr(expr Binary) interface{}
 VisitCallExpr(expr Call) interface{}
+VisitConditionalExpr(expr Conditional) interface{}
+VisitGetExpr(expr Get) interface{}
 VisitGroupingExpr(expr Grouping) interface{}
+VisitIndexExpr(expr Index) interface{}
+VisitLambdaExpr(expr Lambda) interface{}
+VisitListExpr(expr List) interface{}
 VisitLiteralExpr(expr Literal) interface{}
 VisitLogicalExpr(expr Logical) interface{}
+VisitMapExpr(expr Map) interface{}
+VisitSetIndexExpr(expr SetIndex) interface{}
 VisitUnaryExpr(expr Unary) interface{}
+VisitUpdateExpr(expr Update) interface{}
 VisitVariableExpr(expr Variable) interface{}
 }
 type Expr interface {
@@ -47,6 +55,27 @@ return &Call{Callee: callee,Paren: paren,Arguments: arguments}
 func (c *Call) Accept(vis ExprVisitor) interface{} {
 return vis.VisitCallExpr(*c)
 }
+type Conditional struct {
+ Condition Expr
+ ThenBranch Expr
+ ElseBranch Expr
+}
+func NewConditional(condition Expr,thenbranch Expr,elsebranch Expr) *Conditional {
+return &Conditional{Condition: condition,ThenBranch: thenbranch,ElseBranch: elsebranch}
+}
+func (c *Conditional) Accept(vis ExprVisitor) interface{} {
+return vis.VisitConditionalExpr(*c)
+}
+type Get struct {
+ Object Expr
+ Name *token.Token
+}
+func NewGet(object Expr,name *token.Token) *Get {
+return &Get{Object: object,Name: name}
+}
+func (g *Get) Accept(vis ExprVisitor) interface{} {
+return vis.VisitGetExpr(*g)
+}
 type Grouping struct {
  Expression Expr
 }
@@ -56,6 +85,39 @@ return &Grouping{Expression: expression}
 func (g *Grouping) Accept(vis ExprVisitor) interface{} {
 return vis.VisitGroupingExpr(*g)
 }
+type Index struct {
+ Object Expr
+ Bracket *token.Token
+ Index Expr
+}
+func NewIndex(object Expr,bracket *token.Token,index Expr) *Index {
+return &Index{Object: object,Bracket: bracket,Index: index}
+}
+func (i *Index) Accept(vis ExprVisitor) interface{} {
+return vis.VisitIndexExpr(*i)
+}
+type Lambda struct {
+ Keyword *token.Token
+ Params []*token.Token
+ Body []Stmt
+ Generator bool
+}
+func NewLambda(keyword *token.Token,params []*token.Token,body []Stmt,generator bool) *Lambda {
+return &Lambda{Keyword: keyword,Params: params,Body: body,Generator: generator}
+}
+func (l *Lambda) Accept(vis ExprVisitor) interface{} {
+return vis.VisitLambdaExpr(*l)
+}
+type List struct {
+ Bracket *token.Token
+ Elements []Expr
+}
+func NewList(bracket *token.Token,elements []Expr) *List {
+return &List{Bracket: bracket,Elements: elements}
+}
+func (l *List) Accept(vis ExprVisitor) interface{} {
+return vis.VisitListExpr(*l)
+}
 type Literal struct {
  Value interface{}
 }
@@ -76,6 +138,29 @@ return &Logical{Left: left,Operator: operator,Right: right}
 func (l *Logical) Accept(vis ExprVisitor) interface{} {
 return vis.VisitLogicalExpr(*l)
 }
+type Map struct {
+ Brace *token.Token
+ Keys []Expr
+ Values []Expr
+}
+func NewMap(brace *token.Token,keys []Expr,values []Expr) *Map {
+return &Map{Brace: brace,Keys: keys,Values: values}
+}
+func (m *Map) Accept(vis ExprVisitor) interface{} {
+return vis.VisitMapExpr(*m)
+}
+type SetIndex struct {
+ Object Expr
+ Bracket *token.Token
+ Index Expr
+ Value Expr
+}
+func NewSetIndex(object Expr,bracket *token.Token,index Expr,value Expr) *SetIndex {
+return &SetIndex{Object: object,Bracket: bracket,Index: index,Value: value}
+}
+func (s *SetIndex) Accept(vis ExprVisitor) interface{} {
+return vis.VisitSetIndexExpr(*s)
+}
 type Unary struct {
  Operator *token.Token
  Right Expr
@@ -86,6 +171,18 @@ return &Unary{Operator: operator,Right: right}
 func (u *Unary) Accept(vis ExprVisitor) interface{} {
 return vis.VisitUnaryExpr(*u)
 }
+type Update struct {
+ Target Expr
+ Operator *token.Token
+ Value Expr
+ Postfix bool
+}
+func NewUpdate(target Expr,operator *token.Token,value Expr,postfix bool) *Update {
+return &Update{Target: target,Operator: operator,Value: value,Postfix: postfix}
+}
+func (u *Update) Accept(vis ExprVisitor) interface{} {
+return vis.VisitUpdateExpr(*u)
+}
 type Variable struct {
  Name *token.Token
 }
@@ -0,0 +1,245 @@
+package ast
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/iCiaran/golox/token"
+)
+
+type formatter struct {
+	depth int
+}
+
+func Format(statements []Stmt) string {
+	f := &formatter{}
+	var sb strings.Builder
+	for _, stmt := range statements {
+		sb.WriteString(f.statement(stmt))
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
+
+func FormatExpr(expression Expr) string {
+	return (&formatter{}).expr(expression)
+}
+
+func (f *formatter) statement(stmt Stmt) string {
+	return stmt.Accept(f).(string)
+}
+
+func (f *formatter) expr(expression Expr) string {
+	return expression.Accept(f).(string)
+}
+
+func (f *formatter) block(statements []Stmt) string {
+	if len(statements) == 0 {
+		return "{}"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	f.depth++
+	for _, stmt := range statements {
+		sb.WriteString(strings.Repeat("  ", f.depth))
+		sb.WriteString(f.statement(stmt))
+		sb.WriteRune('\n')
+	}
+	f.depth--
+	sb.WriteString(strings.Repeat("  ", f.depth))
+	sb.WriteRune('}')
+	return sb.String()
+}
+
+func (f *formatter) params(params []*token.Token) string {
+	names := make([]string, len(params))
+	for i, param := range params {
+		names[i] = param.Lexeme
+	}
+	return "(" + strings.Join(names, ", ") + ")"
+}
+
+func (f *formatter) list(exprs []Expr) string {
+	formatted := make([]string, len(exprs))
+	for i, expr := range exprs {
+		formatted[i] = f.expr(expr)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func (f *formatter) VisitBlockStmt(stmt Block) interface{} {
+	return f.block(stmt.Statements)
+}
+
+func (f *formatter) VisitExpressionStmt(stmt Expression) interface{} {
+	return f.expr(stmt.Expr) + ";"
+}
+
+func (f *formatter) VisitForInStmt(stmt ForIn) interface{} {
+	return fmt.Sprintf("for (%s in %s) %s", stmt.Name.Lexeme, f.expr(stmt.Iterable), f.statement(stmt.Body))
+}
+
+func (f *formatter) VisitIfStmt(stmt If) interface{} {
+	formatted := fmt.Sprintf("if (%s) %s", f.expr(stmt.Condition), f.statement(stmt.ThenBranch))
+	if stmt.ElseBranch != nil {
+		formatted += " else " + f.statement(stmt.ElseBranch)
+	}
+	return formatted
+}
+
+func (f *formatter) VisitFunctionStmt(stmt Function) interface{} {
+	return "fun " + stmt.Name.Lexeme + f.params(stmt.Params) + " " + f.block(stmt.Body)
+}
+
+func (f *formatter) VisitPrintStmt(stmt Print) interface{} {
+	return "print " + f.expr(stmt.Expr) + ";"
+}
+
+func (f *formatter) VisitReturnStmt(stmt Return) interface{} {
+	if stmt.Value == nil {
+		return "return;"
+	}
+	return "return " + f.expr(stmt.Value) + ";"
+}
+
+func (f *formatter) VisitVarStmt(stmt Var) interface{} {
+	if stmt.Initializer == nil {
+		return "var " + stmt.Name.Lexeme + ";"
+	}
+	return "var " + stmt.Name.Lexeme + " = " + f.expr(stmt.Initializer) + ";"
+}
+
+func (f *formatter) VisitWhileStmt(stmt While) interface{} {
+	if stmt.Keyword.Type == token.FOR {
+		return fmt.Sprintf("for (; %s;) %s", f.expr(stmt.Condition), f.statement(stmt.Body))
+	}
+	return fmt.Sprintf("while (%s) %s", f.expr(stmt.Condition), f.statement(stmt.Body))
+}
+
+func (f *formatter) VisitYieldStmt(stmt Yield) interface{} {
+	if stmt.Value == nil {
+		return "yield;"
+	}
+	return "yield " + f.expr(stmt.Value) + ";"
+}
+
+func (f *formatter) VisitAssignExpr(expr Assign) interface{} {
+	return expr.Name.Lexeme + " = " + f.expr(expr.Value)
+}
+
+func (f *formatter) VisitBinaryExpr(expr Binary) interface{} {
+	if expr.Operator.Type == token.COMMA {
+		return f.expr(expr.Left) + ", " + f.expr(expr.Right)
+	}
+	return f.expr(expr.Left) + " " + expr.Operator.Lexeme + " " + f.expr(expr.Right)
+}
+
+func (f *formatter) VisitCallExpr(expr Call) interface{} {
+	return f.expr(expr.Callee) + "(" + f.list(expr.Arguments) + ")"
+}
+
+func (f *formatter) VisitConditionalExpr(expr Conditional) interface{} {
+	return f.expr(expr.Condition) + " ? " + f.expr(expr.ThenBranch) + " : " + f.expr(expr.ElseBranch)
+}
+
+func (f *formatter) VisitGetExpr(expr Get) interface{} {
+	return f.expr(expr.Object) + "." + expr.Name.Lexeme
+}
+
+func (f *formatter) VisitGroupingExpr(expr Grouping) interface{} {
+	return "(" + f.expr(expr.Expression) + ")"
+}
+
+func (f *formatter) VisitIndexExpr(expr Index) interface{} {
+	return f.expr(expr.Object) + "[" + f.expr(expr.Index) + "]"
+}
+
+func (f *formatter) VisitLambdaExpr(expr Lambda) interface{} {
+	if expr.Keyword.Type != token.ARROW {
+		return "fun " + f.params(expr.Params) + " " + f.block(expr.Body)
+	}
+
+	if len(expr.Body) == 1 {
+		if ret, ok := expr.Body[0].(*Return); ok && ret.Keyword.Type == token.ARROW {
+			return f.params(expr.Params) + " => " + f.expr(ret.Value)
+		}
+	}
+	return f.params(expr.Params) + " => " + f.block(expr.Body)
+}
+
+func (f *formatter) VisitListExpr(expr List) interface{} {
+	return "[" + f.list(expr.Elements) + "]"
+}
+
+func (f *formatter) VisitLiteralExpr(expr Literal) interface{} {
+	switch v := expr.Value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return `"` + v + `"`
+	case float64:
+		formatted := strconv.FormatFloat(v, 'f', -1, 64)
+		if !strings.Contains(formatted, ".") {
+			formatted += ".0"
+		}
+		return formatted
+	case *big.Int:
+		return v.String() + "n"
+	case *big.Rat:
+		return formatDecimal(v) + "m"
+	}
+	return fmt.Sprint(expr.Value)
+}
+
+func (f *formatter) VisitLogicalExpr(expr Logical) interface{} {
+	return f.expr(expr.Left) + " " + expr.Operator.Lexeme + " " + f.expr(expr.Right)
+}
+
+func (f *formatter) VisitMapExpr(expr Map) interface{} {
+	entries := make([]string, len(expr.Keys))
+	for i := range expr.Keys {
+		entries[i] = f.expr(expr.Keys[i]) + ": " + f.expr(expr.Values[i])
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+func (f *formatter) VisitSetIndexExpr(expr SetIndex) interface{} {
+	return f.expr(expr.Object) + "[" + f.expr(expr.Index) + "] = " + f.expr(expr.Value)
+}
+
+func (f *formatter) VisitUnaryExpr(expr Unary) interface{} {
+	right := f.expr(expr.Right)
+	if strings.HasPrefix(right, "-") {
+		return expr.Operator.Lexeme + " " + right
+	}
+	return expr.Operator.Lexeme + right
+}
+
+func (f *formatter) VisitUpdateExpr(expr Update) interface{} {
+	target := f.expr(expr.Target)
+	if expr.Postfix {
+		return target + strings.Repeat(expr.Operator.Lexeme, 2)
+	}
+	increment := expr.Operator.Type == token.PLUS || expr.Operator.Type == token.MINUS
+	if one, ok := expr.Value.(*Literal); ok && increment && one.Value == int64(1) {
+		return strings.Repeat(expr.Operator.Lexeme, 2) + target
+	}
+	return target + " " + expr.Operator.Lexeme + "= " + f.expr(expr.Value)
+}
+
+func (f *formatter) VisitVariableExpr(expr Variable) interface{} {
+	return expr.Name.Lexeme
+}
+
+func formatDecimal(r *big.Rat) string {
+	for prec := 0; prec <= r.Denom().BitLen(); prec++ {
+		formatted := r.FloatString(prec)
+		if parsed, ok := new(big.Rat).SetString(formatted); ok && parsed.Cmp(r) == 0 {
+			return formatted
+		}
+	}
+	return r.RatString()
+}
@@ -27,10 +27,34 @@ func (p *printer) VisitCallExpr(expr Call) interface{} {
 	return p.parenthesise("call", expr.Callee)
 }
 
+func (p *printer) VisitConditionalExpr(expr Conditional) interface{} {
+	return p.parenthesise("?:", expr.Condition, expr.ThenBranch, expr.ElseBranch)
+}
+
+func (p *printer) VisitGetExpr(expr Get) interface{} {
+	return p.parenthesise("."+expr.Name.Lexeme, expr.Object)
+}
+
 func (p *printer) VisitGroupingExpr(expr Grouping) interface{} {
 	return p.parenthesise("group", expr.Expression)
 }
 
+func (p *printer) VisitIndexExpr(expr Index) interface{} {
+	return p.parenthesise("index", expr.Object, expr.Index)
+}
+
+func (p *printer) VisitLambdaExpr(expr Lambda) interface{} {
+	params := make([]string, len(expr.Params))
+	for i, param := range expr.Params {
+		params[i] = param.Lexeme
+	}
+	return fmt.Sprintf("(fun (%s))", strings.Join(params, " "))
+}
+
+func (p *printer) VisitListExpr(expr List) interface{} {
+	return p.parenthesise("list", expr.Elements...)
+}
+
 func (p *printer) VisitLiteralExpr(expr Literal) interface{} {
 	if expr.Value != nil {
 		return fmt.Sprintf("%v", expr.Value)
@@ -42,10 +66,30 @@ func (p *printer) VisitLogicalExpr(expr Logical) interface{} {
 	return p.parenthesise(expr.Operator.Lexeme, expr.Left, expr.Right)
 }
 
+func (p *printer) VisitMapExpr(expr Map) interface{} {
+	entries := make([]Expr, 0, 2*len(expr.Keys))
+	for i := range expr.Keys {
+		entries = append(entries, expr.Keys[i], expr.Values[i])
+	}
+	return p.parenthesise("map", entries...)
+}
+
+func (p *printer) VisitSetIndexExpr(expr SetIndex) interface{} {
+	return p.parenthesise("set-index", expr.Object, expr.Index, expr.Value)
+}
+
 func (p *printer) VisitUnaryExpr(expr Unary) interface{} {
 	return p.parenthesise(expr.Operator.Lexeme, expr.Right)
 }
 
+func (p *printer) VisitUpdateExpr(expr Update) interface{} {
+	name := expr.Operator.Lexeme + "="
+	if expr.Postfix {
+		name = "post" + name
+	}
+	return p.parenthesise(name, expr.Target, expr.Value)
+}
+
 func (p *printer) VisitVariableExpr(expr Variable) interface{} {
 	return expr.Name.Lexeme
 }
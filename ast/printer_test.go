@@ -28,6 +28,22 @@ func TestPrinting(t *testing.T) {
 			},
 			want: "(* (- 123) (group 45.67))",
 		},
+		{
+			input: &Call{
+				&Lambda{
+					&token.Token{Type: token.FUN, Lexeme: "fun", Literal: nil, Line: 1},
+					[]*token.Token{
+						{Type: token.IDENTIFIER, Lexeme: "a", Literal: nil, Line: 1},
+						{Type: token.IDENTIFIER, Lexeme: "b", Literal: nil, Line: 1},
+					},
+					[]Stmt{},
+					false,
+				},
+				&token.Token{Type: token.RIGHT_PAREN, Lexeme: ")", Literal: nil, Line: 1},
+				[]Expr{},
+			},
+			want: "(call (fun (a b)))",
+		},
 	}
 	for i, test := range tests {
 		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
@@ -5,12 +5,14 @@ import (
 type StmtVisitor interface {
 VisitBlockStmt(expr Block) interface{}
 VisitExpressionStmt(expr Expression) interface{}
+VisitForInStmt(expr ForIn) interface{}
 VisitIfStmt(expr If) interface{}
 VisitFunctionStmt(expr Function) interface{}
 VisitPrintStmt(expr Print) interface{}
 VisitReturnStmt(expr Return) interface{}
 VisitVarStmt(expr Var) interface{}
 VisitWhileStmt(expr While) interface{}
+VisitYieldStmt(expr Yield) interface{}
 }
 type Stmt interface {
 Accept(v StmtVisitor) interface{}
@@ -33,13 +35,25 @@ return &Expression{Expr: expr}
 func (e *Expression) Accept(vis StmtVisitor) interface{} {
 return vis.VisitExpressionStmt(*e)
 }
+type ForIn struct {
+ Name *token.Token
+ Iterable Expr
+ Body Stmt
+}
+func NewForIn(name *token.Token,iterable Expr,body Stmt) *ForIn {
+return &ForIn{Name: name,Iterable: iterable,Body: body}
+}
+func (f *ForIn) Accept(vis StmtVisitor) interface{} {
+return vis.VisitForInStmt(*f)
+}
 type If struct {
+ Keyword *token.Token
  Condition Expr
  ThenBranch Stmt
  ElseBranch Stmt
 }
-func NewIf(condition Expr,thenbranch Stmt,elsebranch Stmt) *If {
-return &If{Condition: condition,ThenBranch: thenbranch,ElseBranch: elsebranch}
+func NewIf(keyword *token.Token,condition Expr,thenbranch Stmt,elsebranch Stmt) *If {
+return &If{Keyword: keyword,Condition: condition,ThenBranch: thenbranch,ElseBranch: elsebranch}
 }
 func (i *If) Accept(vis StmtVisitor) interface{} {
 return vis.VisitIfStmt(*i)
@@ -48,18 +62,20 @@ type Function struct {
  Name *token.Token
  Params []*token.Token
  Body []Stmt
+ Generator bool
 }
-func NewFunction(name *token.Token,params []*token.Token,body []Stmt) *Function {
-return &Function{Name: name,Params: params,Body: body}
+func NewFunction(name *token.Token,params []*token.Token,body []Stmt,generator bool) *Function {
+return &Function{Name: name,Params: params,Body: body,Generator: generator}
 }
 func (f *Function) Accept(vis StmtVisitor) interface{} {
 return vis.VisitFunctionStmt(*f)
 }
 type Print struct {
+ Keyword *token.Token
  Expr Expr
 }
-func NewPrint(expr Expr) *Print {
-return &Print{Expr: expr}
+func NewPrint(keyword *token.Token,expr Expr) *Print {
+return &Print{Keyword: keyword,Expr: expr}
 }
 func (p *Print) Accept(vis StmtVisitor) interface{} {
 return vis.VisitPrintStmt(*p)
@@ -85,12 +101,23 @@ func (v *Var) Accept(vis StmtVisitor) interface{} {
 return vis.VisitVarStmt(*v)
 }
 type While struct {
+ Keyword *token.Token
  Condition Expr
  Body Stmt
 }
-func NewWhile(condition Expr,body Stmt) *While {
-return &While{Condition: condition,Body: body}
+func NewWhile(keyword *token.Token,condition Expr,body Stmt) *While {
+return &While{Keyword: keyword,Condition: condition,Body: body}
 }
 func (w *While) Accept(vis StmtVisitor) interface{} {
 return vis.VisitWhileStmt(*w)
 }
+type Yield struct {
+ Keyword *token.Token
+ Value Expr
+}
+func NewYield(keyword *token.Token,value Expr) *Yield {
+return &Yield{Keyword: keyword,Value: value}
+}
+func (y *Yield) Accept(vis StmtVisitor) interface{} {
+return vis.VisitYieldStmt(*y)
+}
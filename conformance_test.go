@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/iCiaran/golox/interpreter"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	expectOutput       = regexp.MustCompile(`// expect: ?(.*)`)
+	expectRuntimeError = regexp.MustCompile(`// expect runtime error: (.+)`)
+	expectSyntaxError  = regexp.MustCompile(`// (\[line (\d+)\] )?Error( at [^:]+)?: (.+)`)
+)
+
+type expectations struct {
+	output       []string
+	errors       []string
+	runtimeError string
+	code         int
+}
+
+func parseExpectations(source string) expectations {
+	var e expectations
+	for n, line := range strings.Split(source, "\n") {
+		if m := expectOutput.FindStringSubmatch(line); m != nil {
+			e.output = append(e.output, m[1])
+		} else if m := expectRuntimeError.FindStringSubmatch(line); m != nil {
+			e.runtimeError = fmt.Sprintf("[%d] Error : %s", n+1, m[1])
+			e.code = 70
+		} else if m := expectSyntaxError.FindStringSubmatch(line); m != nil {
+			number := n + 1
+			if m[2] != "" {
+				number, _ = strconv.Atoi(m[2])
+			}
+			e.errors = append(e.errors, fmt.Sprintf("[%d] Error %s: %s", number, strings.TrimSpace(m[3]), m[4]))
+			e.code = 65
+		}
+	}
+	return e
+}
+
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func TestConformance(t *testing.T) {
+	var paths []string
+	err := filepath.Walk("testdata", func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && filepath.Ext(path) == ".lox" {
+			paths = append(paths, path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no conformance tests found under testdata")
+	}
+
+	for _, path := range paths {
+		path := path
+		t.Run(filepath.ToSlash(strings.TrimSuffix(strings.TrimPrefix(path, "testdata"+string(filepath.Separator)), ".lox")), func(t *testing.T) {
+			assert := assert.New(t)
+
+			source, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := parseExpectations(string(source))
+
+			var stdout, stderr bytes.Buffer
+			in := interpreter.NewInterpreter()
+			in.SetStdout(&stdout)
+			in.SetErrorOutput(&stderr)
+			code := runFile(in, path)
+
+			assert.Equal(want.code, code, "exit code")
+			assert.Equal(want.output, lines(stdout.String()), "stdout")
+
+			errors := lines(stderr.String())
+			switch {
+			case want.errors != nil:
+				assert.Equal(want.errors, errors, "compile errors")
+			case want.runtimeError != "":
+				if assert.NotEmpty(errors, "runtime error") {
+					assert.Equal(want.runtimeError, errors[0], "runtime error")
+				}
+			default:
+				assert.Empty(errors, "stderr")
+			}
+		})
+	}
+}
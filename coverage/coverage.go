@@ -0,0 +1,194 @@
+package coverage
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/interpreter"
+	"github.com/iCiaran/golox/token"
+)
+
+type Coverage struct {
+	mu         sync.Mutex
+	filename   string
+	source     []string
+	statements map[position]int
+	branches   map[position]*branch
+}
+
+type position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type branch struct {
+	Then int `json:"then"`
+	Else int `json:"else"`
+}
+
+func New(filename, source string, statements []ast.Stmt) *Coverage {
+	c := &Coverage{
+		filename:   filename,
+		source:     strings.Split(source, "\n"),
+		statements: make(map[position]int),
+		branches:   make(map[position]*branch),
+	}
+	c.walkStatements(statements)
+	return c
+}
+
+func (c *Coverage) Statement(in *interpreter.Interpreter, stmt ast.Stmt) {
+	t := interpreter.StatementToken(stmt)
+	if t == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.statements[at(t)]++
+	c.mu.Unlock()
+}
+
+func (c *Coverage) Branch(in *interpreter.Interpreter, keyword *token.Token, taken bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.branches[at(keyword)]
+	if !ok {
+		b = &branch{}
+		c.branches[at(keyword)] = b
+	}
+	if taken {
+		b.Then++
+	} else {
+		b.Else++
+	}
+}
+
+func at(t *token.Token) position {
+	return position{t.Line, t.Column}
+}
+
+func (c *Coverage) walkStatements(statements []ast.Stmt) {
+	for _, stmt := range statements {
+		c.walkStatement(stmt)
+	}
+}
+
+func (c *Coverage) walkStatement(stmt ast.Stmt) {
+	if stmt == nil {
+		return
+	}
+	if t := interpreter.StatementToken(stmt); t != nil {
+		c.statements[at(t)] += 0
+	}
+
+	switch s := stmt.(type) {
+	case *ast.Block:
+		c.walkStatements(s.Statements)
+	case *ast.Expression:
+		c.walkExpression(s.Expr)
+	case *ast.ForIn:
+		c.walkExpression(s.Iterable)
+		c.walkStatement(s.Body)
+	case *ast.If:
+		c.branches[at(s.Keyword)] = &branch{}
+		c.walkExpression(s.Condition)
+		c.walkStatement(s.ThenBranch)
+		c.walkStatement(s.ElseBranch)
+	case *ast.Function:
+		c.walkStatements(s.Body)
+	case *ast.Print:
+		c.walkExpression(s.Expr)
+	case *ast.Return:
+		c.walkExpression(s.Value)
+	case *ast.Var:
+		c.walkExpression(s.Initializer)
+	case *ast.While:
+		c.walkExpression(s.Condition)
+		c.walkStatement(s.Body)
+	case *ast.Yield:
+		c.walkExpression(s.Value)
+	}
+}
+
+func (c *Coverage) walkExpressions(expressions []ast.Expr) {
+	for _, expr := range expressions {
+		c.walkExpression(expr)
+	}
+}
+
+func (c *Coverage) walkExpression(expr ast.Expr) {
+	switch e := expr.(type) {
+	case *ast.Assign:
+		c.walkExpression(e.Value)
+	case *ast.Binary:
+		c.walkExpressions([]ast.Expr{e.Left, e.Right})
+	case *ast.Call:
+		c.walkExpression(e.Callee)
+		c.walkExpressions(e.Arguments)
+	case *ast.Conditional:
+		c.walkExpressions([]ast.Expr{e.Condition, e.ThenBranch, e.ElseBranch})
+	case *ast.Get:
+		c.walkExpression(e.Object)
+	case *ast.Grouping:
+		c.walkExpression(e.Expression)
+	case *ast.Index:
+		c.walkExpressions([]ast.Expr{e.Object, e.Index})
+	case *ast.Lambda:
+		c.walkStatements(e.Body)
+	case *ast.List:
+		c.walkExpressions(e.Elements)
+	case *ast.Logical:
+		c.walkExpressions([]ast.Expr{e.Left, e.Right})
+	case *ast.Map:
+		c.walkExpressions(e.Keys)
+		c.walkExpressions(e.Values)
+	case *ast.SetIndex:
+		c.walkExpressions([]ast.Expr{e.Object, e.Index, e.Value})
+	case *ast.Unary:
+		c.walkExpression(e.Right)
+	case *ast.Update:
+		c.walkExpressions([]ast.Expr{e.Target, e.Value})
+	}
+}
+
+type statementCount struct {
+	position
+	Count int `json:"count"`
+}
+
+type branchCount struct {
+	position
+	branch
+}
+
+func (c *Coverage) snapshot() ([]statementCount, []branchCount) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	statements := make([]statementCount, 0, len(c.statements))
+	for p, count := range c.statements {
+		statements = append(statements, statementCount{p, count})
+	}
+	sort.Slice(statements, func(a, b int) bool {
+		return before(statements[a].position, statements[b].position)
+	})
+
+	branches := make([]branchCount, 0, len(c.branches))
+	for p, b := range c.branches {
+		branches = append(branches, branchCount{p, *b})
+	}
+	sort.Slice(branches, func(a, b int) bool {
+		return before(branches[a].position, branches[b].position)
+	})
+	return statements, branches
+}
+
+func before(a, b position) bool {
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Column < b.Column
+}
@@ -0,0 +1,104 @@
+package coverage
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/iCiaran/golox/interpreter"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/parser"
+	"github.com/iCiaran/golox/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+const script = `fun sign(n) {
+  if (n < 0) {
+    return -1;
+  } else if (n > 0) {
+    return 1;
+  }
+  return 0;
+}
+var double = fun (x) { return x * 2; };
+for (var i = 0; i < 2; i++) sign(i);`
+
+func run(t *testing.T) *Coverage {
+	reporter := loxerror.NewReporter(ioutil.Discard)
+	statements := parser.NewParser(scanner.New(script, reporter).ScanTokens(), reporter).Parse()
+	c := New("sign.lox", script, statements)
+
+	in := interpreter.NewInterpreter()
+	in.SetCoverage(c)
+	if err := in.InterpretContext(context.Background(), statements); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCoverage(t *testing.T) {
+	assert := assert.New(t)
+
+	c := run(t)
+	statements, branches := c.snapshot()
+	assert.Equal([]statementCount{
+		{position{1, 5}, 1},
+		{position{2, 3}, 2},
+		{position{3, 5}, 0},
+		{position{4, 10}, 2},
+		{position{5, 5}, 1},
+		{position{7, 3}, 1},
+		{position{9, 5}, 1},
+		{position{9, 24}, 0},
+		{position{10, 1}, 1},
+		{position{10, 10}, 1},
+		{position{10, 24}, 2},
+		{position{10, 29}, 2},
+	}, statements)
+	assert.Equal([]branchCount{
+		{position{2, 3}, branch{0, 2}},
+		{position{4, 10}, branch{1, 1}},
+	}, branches)
+	assert.Equal("coverage: 83.3% of statements (10/12), 75.0% of branches (3/4)", c.Summary())
+}
+
+func TestLCOV(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	assert.Nil(run(t).WriteLCOV(&out))
+	assert.Equal(`TN:
+SF:sign.lox
+DA:1,1
+DA:2,2
+DA:3,0
+DA:4,2
+DA:5,1
+DA:7,1
+DA:9,1
+DA:10,2
+LF:8
+LH:7
+BRDA:2,0,0,0
+BRDA:2,0,1,2
+BRDA:4,1,0,1
+BRDA:4,1,1,1
+BRF:4
+BRH:3
+end_of_record
+`, out.String())
+}
+
+func TestHTML(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	assert.Nil(run(t).WriteHTML(&out))
+	html := out.String()
+	assert.True(strings.Contains(html, `<span class="line uncovered" title="executed 0 times"><span class="number">3</span>    return -1;</span>`))
+	assert.True(strings.Contains(html, `<span class="line partial" title="executed 2 times, not fully covered"><span class="number">2</span>  if (n &lt; 0) {</span>`))
+	assert.True(strings.Contains(html, `<span class="line covered" title="executed 1 times"><span class="number">5</span>    return 1;</span>`))
+	assert.True(strings.Contains(html, `<span class="line " title=""><span class="number">8</span>}</span>`))
+}
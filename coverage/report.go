@@ -0,0 +1,179 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+)
+
+type report struct {
+	File       string           `json:"file"`
+	Statements []statementCount `json:"statements"`
+	Branches   []branchCount    `json:"branches"`
+}
+
+func (c *Coverage) WriteJSON(w io.Writer) error {
+	statements, branches := c.snapshot()
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report{c.filename, statements, branches})
+}
+
+func (c *Coverage) WriteLCOV(w io.Writer) error {
+	statements, branches := c.snapshot()
+	lines := lineCounts(statements)
+
+	fmt.Fprintf(w, "TN:\nSF:%s\n", c.filename)
+	hit := 0
+	for _, line := range sortedLines(lines) {
+		fmt.Fprintf(w, "DA:%d,%d\n", line, lines[line])
+		if lines[line] > 0 {
+			hit++
+		}
+	}
+	fmt.Fprintf(w, "LF:%d\nLH:%d\n", len(lines), hit)
+
+	hit = 0
+	for n, b := range branches {
+		for i, taken := range []int{b.Then, b.Else} {
+			if b.Then+b.Else == 0 {
+				fmt.Fprintf(w, "BRDA:%d,%d,%d,-\n", b.Line, n, i)
+				continue
+			}
+			fmt.Fprintf(w, "BRDA:%d,%d,%d,%d\n", b.Line, n, i, taken)
+			if taken > 0 {
+				hit++
+			}
+		}
+	}
+	fmt.Fprintf(w, "BRF:%d\nBRH:%d\n", 2*len(branches), hit)
+
+	_, err := fmt.Fprintln(w, "end_of_record")
+	return err
+}
+
+type htmlLine struct {
+	Number int
+	Text   string
+	Class  string
+	Title  string
+}
+
+var page = template.Must(template.New("coverage").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.File}}</title>
+<style>
+body { font-family: sans-serif; }
+pre { font-family: monospace; margin: 0; }
+.line { display: block; white-space: pre; }
+.number { display: inline-block; width: 4em; color: #888; text-align: right; margin-right: 1em; }
+.covered { background: #dfd; }
+.uncovered { background: #fdd; }
+.partial { background: #ffd; }
+</style>
+</head>
+<body>
+<h1>{{.File}}</h1>
+<p>{{.Summary}}</p>
+<pre>
+{{- range .Lines}}
+<span class="line {{.Class}}" title="{{.Title}}"><span class="number">{{.Number}}</span>{{.Text}}</span>
+{{- end}}
+</pre>
+</body>
+</html>
+`))
+
+func (c *Coverage) WriteHTML(w io.Writer) error {
+	statements, branches := c.snapshot()
+	counts := lineCounts(statements)
+	partial := make(map[int]bool)
+	for _, s := range statements {
+		if s.Count == 0 {
+			partial[s.Line] = true
+		}
+	}
+	for _, b := range branches {
+		if b.Then == 0 || b.Else == 0 {
+			partial[b.Line] = true
+		}
+	}
+
+	lines := make([]htmlLine, len(c.source))
+	for n, text := range c.source {
+		line := htmlLine{Number: n + 1, Text: text}
+		if count, ok := counts[n+1]; ok {
+			line.Title = fmt.Sprintf("executed %d times", count)
+			switch {
+			case count == 0:
+				line.Class = "uncovered"
+			case partial[n+1]:
+				line.Class = "partial"
+				line.Title += ", not fully covered"
+			default:
+				line.Class = "covered"
+			}
+		}
+		lines[n] = line
+	}
+
+	return page.Execute(w, struct {
+		File    string
+		Summary string
+		Lines   []htmlLine
+	}{c.filename, c.Summary(), lines})
+}
+
+func (c *Coverage) Summary() string {
+	statements, branches := c.snapshot()
+
+	covered := 0
+	for _, s := range statements {
+		if s.Count > 0 {
+			covered++
+		}
+	}
+	taken := 0
+	for _, b := range branches {
+		if b.Then > 0 {
+			taken++
+		}
+		if b.Else > 0 {
+			taken++
+		}
+	}
+
+	return fmt.Sprintf("coverage: %s of statements (%d/%d), %s of branches (%d/%d)",
+		percent(covered, len(statements)), covered, len(statements),
+		percent(taken, 2*len(branches)), taken, 2*len(branches))
+}
+
+func percent(n, total int) string {
+	if total == 0 {
+		return "100.0%"
+	}
+	return fmt.Sprintf("%.1f%%", 100*float64(n)/float64(total))
+}
+
+func lineCounts(statements []statementCount) map[int]int {
+	lines := make(map[int]int)
+	for _, s := range statements {
+		if count, ok := lines[s.Line]; !ok || s.Count > count {
+			lines[s.Line] = s.Count
+		}
+	}
+	return lines
+}
+
+func sortedLines(lines map[int]int) []int {
+	sorted := make([]int, 0, len(lines))
+	for line := range lines {
+		sorted = append(sorted, line)
+	}
+	sort.Ints(sorted)
+	return sorted
+}
@@ -0,0 +1,231 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iCiaran/golox/interpreter"
+	"github.com/stretchr/testify/assert"
+)
+
+const script = `fun add(a, b) {
+  var sum = a + b;
+  return sum;
+}
+var total = [0];
+total[0] = add(total[0], 2);
+print total;`
+
+type client struct {
+	t        *testing.T
+	in       *io.PipeWriter
+	messages chan *message
+	seq      int
+	events   []*message
+}
+
+func newClient(t *testing.T, setup func(in *interpreter.Interpreter)) *client {
+	requests, input := io.Pipe()
+	output, responses := io.Pipe()
+	go func() {
+		server := NewServer(requests, responses)
+		server.Setup = setup
+		server.Serve()
+		responses.Close()
+	}()
+
+	messages := make(chan *message, 100)
+	go func() {
+		defer close(messages)
+		reader := bufio.NewReader(output)
+		for {
+			m, err := readMessage(reader)
+			if err != nil {
+				return
+			}
+			messages <- m
+		}
+	}()
+	return &client{t: t, in: input, messages: messages}
+}
+
+func (c *client) request(command string, arguments interface{}) *message {
+	c.seq++
+	body, _ := json.Marshal(arguments)
+	if err := writeMessage(c.in, map[string]interface{}{"seq": c.seq, "type": "request", "command": command, "arguments": json.RawMessage(body)}); err != nil {
+		c.t.Fatal(err)
+	}
+
+	for {
+		m := c.read()
+		if m.Type == "response" && m.RequestSeq == c.seq {
+			return m
+		}
+		c.events = append(c.events, m)
+	}
+}
+
+func (c *client) event(name string) *message {
+	for n, m := range c.events {
+		if m.Event == name {
+			c.events = append(c.events[:n], c.events[n+1:]...)
+			return m
+		}
+	}
+
+	for {
+		m := c.read()
+		if m.Event == name {
+			return m
+		}
+		c.events = append(c.events, m)
+	}
+}
+
+func (c *client) read() *message {
+	m, ok := <-c.messages
+	if !ok {
+		c.t.Fatal("connection closed")
+	}
+	return m
+}
+
+func body(m *message) map[string]interface{} {
+	var b map[string]interface{}
+	json.Unmarshal(m.Body, &b)
+	return b
+}
+
+func TestServer(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "golox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	program := filepath.Join(dir, "add.lox")
+	ioutil.WriteFile(program, []byte(script), 0644)
+
+	c := newClient(t, nil)
+
+	assert.True(c.request("initialize", map[string]interface{}{"adapterID": "golox"}).Success)
+	c.event("initialized")
+	assert.True(c.request("launch", map[string]interface{}{"program": program}).Success)
+
+	breakpoints := body(c.request("setBreakpoints", map[string]interface{}{"breakpoints": []map[string]int{{"line": 3}}}))
+	assert.Equal([]interface{}{map[string]interface{}{"line": 3.0, "verified": true}}, breakpoints["breakpoints"])
+
+	assert.False(c.request("stackTrace", map[string]int{"threadId": 1}).Success)
+	assert.True(c.request("configurationDone", nil).Success)
+	stopped := body(c.event("stopped"))
+	assert.Equal("breakpoint", stopped["reason"])
+
+	frames := body(c.request("stackTrace", map[string]int{"threadId": 1}))["stackFrames"].([]interface{})
+	assert.Len(frames, 2)
+	assert.Equal("<fn add>", frames[0].(map[string]interface{})["name"])
+	assert.Equal(3.0, frames[0].(map[string]interface{})["line"])
+	assert.Equal("<script>", frames[1].(map[string]interface{})["name"])
+	assert.Equal(6.0, frames[1].(map[string]interface{})["line"])
+
+	scopes := body(c.request("scopes", map[string]int{"frameId": 0}))["scopes"].([]interface{})
+	locals := scopes[0].(map[string]interface{})["variablesReference"]
+	globals := scopes[1].(map[string]interface{})["variablesReference"]
+
+	variables := body(c.request("variables", map[string]interface{}{"variablesReference": locals}))
+	assert.Equal([]interface{}{
+		map[string]interface{}{"name": "a", "value": "0", "variablesReference": 0.0},
+		map[string]interface{}{"name": "b", "value": "2", "variablesReference": 0.0},
+		map[string]interface{}{"name": "sum", "value": "2", "variablesReference": 0.0},
+	}, variables["variables"])
+
+	list := body(c.request("variables", map[string]interface{}{"variablesReference": globals}))["variables"].([]interface{})
+	assert.Len(list, 3)
+	total := list[2].(map[string]interface{})
+	assert.Equal("total", total["name"])
+	assert.Equal("[0]", total["value"])
+
+	variables = body(c.request("variables", map[string]interface{}{"variablesReference": total["variablesReference"]}))
+	assert.Equal([]interface{}{map[string]interface{}{"name": "0", "value": "0", "variablesReference": 0.0}}, variables["variables"])
+
+	assert.Equal("20", body(c.request("evaluate", map[string]interface{}{"expression": "sum * 10", "frameId": 0}))["result"])
+	failed := c.request("evaluate", map[string]interface{}{"expression": "nope", "frameId": 0})
+	assert.False(failed.Success)
+	assert.Equal("Undefined variable 'nope'.", failed.Message)
+
+	assert.True(c.request("next", map[string]int{"threadId": 1}).Success)
+	assert.Equal("step", body(c.event("stopped"))["reason"])
+	frames = body(c.request("stackTrace", map[string]int{"threadId": 1}))["stackFrames"].([]interface{})
+	assert.Equal(7.0, frames[0].(map[string]interface{})["line"])
+
+	assert.True(c.request("continue", map[string]int{"threadId": 1}).Success)
+	output := body(c.event("output"))
+	assert.Equal("stdout", output["category"])
+	assert.Equal("[2]\n", output["output"])
+	assert.Equal(0.0, body(c.event("exited"))["exitCode"])
+	c.event("terminated")
+
+	assert.True(c.request("disconnect", nil).Success)
+}
+
+func TestServerDisconnect(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "golox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	program := filepath.Join(dir, "loop.lox")
+	ioutil.WriteFile(program, []byte("var i = 0;\nwhile (true) {\n  i = i + 1;\n}"), 0644)
+
+	c := newClient(t, nil)
+	c.request("initialize", nil)
+	assert.False(c.request("launch", map[string]string{"program": filepath.Join(dir, "missing.lox")}).Success)
+	assert.True(c.request("launch", map[string]interface{}{"program": program, "stopOnEntry": true}).Success)
+	c.request("configurationDone", nil)
+	assert.Equal("entry", body(c.event("stopped"))["reason"])
+
+	c.request("continue", nil)
+	c.request("pause", nil)
+	assert.Equal("pause", body(c.event("stopped"))["reason"])
+	assert.Equal("true", body(c.request("evaluate", map[string]interface{}{"expression": "i >= 0"}))["result"])
+
+	assert.True(c.request("disconnect", nil).Success)
+	assert.Equal(70.0, body(c.event("exited"))["exitCode"])
+}
+
+func TestServerSetup(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "golox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	program := filepath.Join(dir, "deep.lox")
+	ioutil.WriteFile(program, []byte("print readLine();\nfun f(n) {\n  return f(n + 1);\n}\nf(0);"), 0644)
+
+	c := newClient(t, func(in *interpreter.Interpreter) {
+		in.SetMaxDepth(3)
+	})
+	c.request("initialize", nil)
+	assert.True(c.request("launch", map[string]interface{}{"program": program}).Success)
+	c.request("configurationDone", nil)
+
+	output := body(c.event("output"))
+	assert.Equal("stdout", output["category"])
+	assert.Equal("nil\n", output["output"])
+	output = body(c.event("output"))
+	assert.Equal("stderr", output["category"])
+	assert.Contains(output["output"], "Stack overflow.")
+	assert.Equal(70.0, body(c.event("exited"))["exitCode"])
+	c.event("terminated")
+
+	assert.True(c.request("disconnect", nil).Success)
+}
@@ -0,0 +1,514 @@
+package dap
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/debugger"
+	"github.com/iCiaran/golox/environment"
+	"github.com/iCiaran/golox/interpreter"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/parser"
+	"github.com/iCiaran/golox/scanner"
+)
+
+const threadID = 1
+
+type Server struct {
+	Setup func(in *interpreter.Interpreter)
+
+	input   *bufio.Reader
+	outMu   sync.Mutex
+	out     io.Writer
+	seq     int
+	program string
+
+	interpreter *interpreter.Interpreter
+	statements  []ast.Stmt
+	cancel      context.CancelFunc
+	done        chan struct{}
+
+	mu       sync.Mutex
+	hookMu   sync.Mutex
+	stepper  *debugger.Stepper
+	entry    bool
+	pausing  bool
+	stopped  *stopState
+	work     chan func()
+	resume   chan struct{}
+	quitting bool
+}
+
+type stopState struct {
+	interpreter *interpreter.Interpreter
+	line        int
+	references  []interface{}
+}
+
+type scopeValues map[string]interface{}
+
+func NewServer(input io.Reader, out io.Writer) *Server {
+	return &Server{
+		input:   bufio.NewReader(input),
+		out:     out,
+		stepper: debugger.NewStepper(),
+		work:    make(chan func()),
+		resume:  make(chan struct{}),
+	}
+}
+
+func (s *Server) Serve() error {
+	for {
+		request, err := readMessage(s.input)
+		if err == io.EOF {
+			s.terminate()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if request.Type != "request" {
+			continue
+		}
+
+		if !s.handle(request) {
+			return nil
+		}
+	}
+}
+
+func (s *Server) handle(request *message) bool {
+	switch request.Command {
+	case "initialize":
+		s.respond(request, map[string]interface{}{
+			"supportsConfigurationDoneRequest": true,
+			"supportsEvaluateForHovers":        true,
+		})
+		s.event("initialized", nil)
+	case "launch":
+		s.launch(request)
+	case "setBreakpoints":
+		s.setBreakpoints(request)
+	case "configurationDone":
+		s.respond(request, nil)
+		s.start()
+	case "threads":
+		s.respond(request, map[string]interface{}{"threads": []thread{{threadID, "main"}}})
+	case "stackTrace":
+		s.stackTrace(request)
+	case "scopes":
+		s.scopes(request)
+	case "variables":
+		s.variables(request)
+	case "evaluate":
+		s.evaluate(request)
+	case "continue":
+		s.proceed(request, debugger.Run)
+	case "next":
+		s.proceed(request, debugger.StepOver)
+	case "stepIn":
+		s.proceed(request, debugger.StepIn)
+	case "stepOut":
+		s.proceed(request, debugger.StepOut)
+	case "pause":
+		s.mu.Lock()
+		s.pausing = true
+		s.mu.Unlock()
+		s.respond(request, nil)
+	case "disconnect", "terminate":
+		s.terminate()
+		s.respond(request, nil)
+		return request.Command != "disconnect"
+	default:
+		s.fail(request, fmt.Sprintf("Unsupported request '%s'.", request.Command))
+	}
+	return true
+}
+
+func (s *Server) launch(request *message) {
+	var arguments struct {
+		Program     string   `json:"program"`
+		Args        []string `json:"args"`
+		StopOnEntry bool     `json:"stopOnEntry"`
+	}
+	if err := json.Unmarshal(request.Arguments, &arguments); err != nil {
+		s.fail(request, err.Error())
+		return
+	}
+
+	text, err := ioutil.ReadFile(arguments.Program)
+	if err != nil {
+		s.fail(request, fmt.Sprintf("Cannot read '%s': %v.", arguments.Program, err))
+		return
+	}
+
+	stderr := &output{s, "stderr"}
+	reporter := loxerror.NewReporter(stderr)
+	statements := parser.NewParser(scanner.New(string(text), reporter).ScanTokens(), reporter).Parse()
+	if reporter.HadError() {
+		s.fail(request, fmt.Sprintf("Cannot parse '%s'.", arguments.Program))
+		return
+	}
+
+	// The server's own input carries the protocol, so the script gets an
+	// empty stdin rather than reading (and blocking on) protocol messages.
+	in := interpreter.NewInterpreter()
+	in.SetStdin(strings.NewReader(""))
+	in.SetStdout(&output{s, "stdout"})
+	in.SetErrorOutput(stderr)
+	in.SetArgs(arguments.Args)
+	if s.Setup != nil {
+		s.Setup(in)
+	}
+	in.SetDebugger(s)
+
+	s.mu.Lock()
+	s.entry = arguments.StopOnEntry
+	if arguments.StopOnEntry {
+		s.stepper.Mode = debugger.StepIn
+	} else {
+		s.stepper.Mode = debugger.Run
+	}
+	s.mu.Unlock()
+
+	s.program = arguments.Program
+	s.interpreter = in
+	s.statements = statements
+	s.respond(request, nil)
+}
+
+func (s *Server) setBreakpoints(request *message) {
+	var arguments struct {
+		Breakpoints []struct {
+			Line int `json:"line"`
+		} `json:"breakpoints"`
+	}
+	if err := json.Unmarshal(request.Arguments, &arguments); err != nil {
+		s.fail(request, err.Error())
+		return
+	}
+
+	breakpoints := make([]breakpoint, len(arguments.Breakpoints))
+	s.mu.Lock()
+	s.stepper.Breakpoints = make(map[int]bool)
+	for n, b := range arguments.Breakpoints {
+		s.stepper.Breakpoints[b.Line] = true
+		breakpoints[n] = breakpoint{b.Line, true}
+	}
+	s.mu.Unlock()
+
+	s.respond(request, map[string]interface{}{"breakpoints": breakpoints})
+}
+
+func (s *Server) start() {
+	if s.interpreter == nil || s.done != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		code := 0
+		switch e := s.interpreter.InterpretContext(ctx, s.statements).(type) {
+		case nil:
+		case *interpreter.ExitError:
+			code = e.Code
+		case *loxerror.Runtime:
+			code = 70
+		default:
+			if !s.isQuitting() {
+				fmt.Fprintln(&output{s, "stderr"}, e)
+			}
+			code = 70
+		}
+
+		s.event("exited", map[string]interface{}{"exitCode": code})
+		s.event("terminated", nil)
+	}()
+}
+
+func (s *Server) terminate() {
+	s.mu.Lock()
+	s.quitting = true
+	stopped := s.stopped != nil
+	s.mu.Unlock()
+
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	if stopped {
+		s.resume <- struct{}{}
+	}
+	<-s.done
+}
+
+func (s *Server) isQuitting() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.quitting
+}
+
+func (s *Server) Statement(in *interpreter.Interpreter, stmt ast.Stmt, line int) {
+	s.hookMu.Lock()
+	defer s.hookMu.Unlock()
+
+	s.mu.Lock()
+	reason := "step"
+	if s.stepper.AtBreakpoint(line, in.Depth()) {
+		reason = "breakpoint"
+	}
+	stop := s.stepper.ShouldStop(line, in.Depth())
+	if s.pausing {
+		stop, reason, s.pausing = true, "pause", false
+	}
+	if stop && s.entry {
+		reason, s.entry = "entry", false
+	}
+	if s.quitting {
+		stop = false
+	}
+	if stop {
+		s.stopped = &stopState{interpreter: in, line: line}
+	}
+	s.mu.Unlock()
+
+	if !stop {
+		return
+	}
+
+	s.event("stopped", map[string]interface{}{"reason": reason, "threadId": threadID, "allThreadsStopped": true})
+	for {
+		select {
+		case f := <-s.work:
+			f()
+		case <-s.resume:
+			s.mu.Lock()
+			s.stopped = nil
+			quitting := s.quitting
+			s.mu.Unlock()
+			if quitting {
+				panic(&interpreter.CancelledError{Err: context.Canceled})
+			}
+			return
+		}
+	}
+}
+
+func (s *Server) inspect(request *message, f func(state *stopState)) {
+	s.mu.Lock()
+	state := s.stopped
+	s.mu.Unlock()
+
+	if state == nil {
+		s.fail(request, "The program is not stopped.")
+		return
+	}
+
+	done := make(chan struct{})
+	s.work <- func() {
+		f(state)
+		close(done)
+	}
+	<-done
+}
+
+func (s *Server) proceed(request *message, mode debugger.Mode) {
+	s.mu.Lock()
+	stopped := s.stopped != nil
+	s.stepper.Mode = mode
+	s.mu.Unlock()
+
+	s.respond(request, map[string]interface{}{"allThreadsContinued": true})
+	if stopped {
+		s.resume <- struct{}{}
+	}
+}
+
+func (s *Server) stackTrace(request *message) {
+	s.inspect(request, func(state *stopState) {
+		frames := state.interpreter.StackTrace(state.line)
+		stack := make([]stackFrame, len(frames))
+		for n, frame := range frames {
+			stack[n] = stackFrame{n, frame.Name, frame.Line, 1, source{filepath.Base(s.program), s.program}}
+		}
+		s.respond(request, map[string]interface{}{"stackFrames": stack, "totalFrames": len(stack)})
+	})
+}
+
+func (s *Server) scopes(request *message) {
+	var arguments struct {
+		FrameID int `json:"frameId"`
+	}
+	json.Unmarshal(request.Arguments, &arguments)
+
+	s.inspect(request, func(state *stopState) {
+		env, ok := s.frameEnvironment(state, arguments.FrameID)
+		if !ok {
+			s.fail(request, "Unknown frame.")
+			return
+		}
+
+		globals := state.interpreter.Globals()
+		locals := make(scopeValues)
+		for ; env != nil && env != globals; env = env.Enclosing() {
+			for name, value := range env.Values() {
+				if _, shadowed := locals[name]; !shadowed {
+					locals[name] = value
+				}
+			}
+		}
+
+		s.respond(request, map[string]interface{}{"scopes": []scope{
+			{"Locals", state.reference(locals), false},
+			{"Globals", state.reference(scopeValues(globals.Values())), false},
+		}})
+	})
+}
+
+func (s *Server) variables(request *message) {
+	var arguments struct {
+		VariablesReference int `json:"variablesReference"`
+	}
+	json.Unmarshal(request.Arguments, &arguments)
+
+	s.inspect(request, func(state *stopState) {
+		n := arguments.VariablesReference - 1
+		if n < 0 || n >= len(state.references) {
+			s.fail(request, "Unknown variables reference.")
+			return
+		}
+
+		variables := make([]variable, 0)
+		switch value := state.references[n].(type) {
+		case scopeValues:
+			names := make([]string, 0, len(value))
+			for name, v := range value {
+				if !interpreter.IsBuiltin(v) {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				variables = append(variables, state.variable(name, value[name]))
+			}
+		case *interpreter.List:
+			for i, element := range value.Elements() {
+				variables = append(variables, state.variable(fmt.Sprint(i), element))
+			}
+		case *interpreter.Map:
+			keys, values := value.Entries()
+			for i := range keys {
+				variables = append(variables, state.variable(interpreter.Stringify(keys[i]), values[i]))
+			}
+		}
+		s.respond(request, map[string]interface{}{"variables": variables})
+	})
+}
+
+func (s *Server) evaluate(request *message) {
+	var arguments struct {
+		Expression string `json:"expression"`
+		FrameID    int    `json:"frameId"`
+	}
+	json.Unmarshal(request.Arguments, &arguments)
+
+	s.inspect(request, func(state *stopState) {
+		env, ok := s.frameEnvironment(state, arguments.FrameID)
+		if !ok {
+			s.fail(request, "Unknown frame.")
+			return
+		}
+
+		reporter := loxerror.NewReporter(ioutil.Discard)
+		tokens := scanner.New(arguments.Expression, reporter).ScanTokens()
+		var expr ast.Expr
+		if !reporter.HadError() {
+			expr = parser.NewParser(tokens, reporter).ParseExpression()
+		}
+		if reporter.HadError() {
+			s.fail(request, fmt.Sprintf("Cannot parse '%s'.", arguments.Expression))
+			return
+		}
+
+		value, err := state.interpreter.Evaluate(expr, env)
+		if err != nil {
+			s.fail(request, err.Error())
+			return
+		}
+
+		v := state.variable("", value)
+		s.respond(request, map[string]interface{}{"result": v.Value, "variablesReference": v.VariablesReference})
+	})
+}
+
+func (s *Server) frameEnvironment(state *stopState, id int) (*environment.Environment, bool) {
+	frames := state.interpreter.StackTrace(state.line)
+	if id < 0 || id >= len(frames) {
+		return nil, false
+	}
+	return frames[id].Environment, true
+}
+
+func (state *stopState) reference(value interface{}) int {
+	state.references = append(state.references, value)
+	return len(state.references)
+}
+
+func (state *stopState) variable(name string, value interface{}) variable {
+	v := variable{Name: name, Value: interpreter.Stringify(value)}
+	switch value.(type) {
+	case *interpreter.List, *interpreter.Map:
+		v.VariablesReference = state.reference(value)
+	}
+	return v
+}
+
+func (s *Server) respond(request *message, body interface{}) {
+	s.send(&response{Type: "response", RequestSeq: request.Seq, Success: true, Command: request.Command, Body: body})
+}
+
+func (s *Server) fail(request *message, text string) {
+	s.send(&response{Type: "response", RequestSeq: request.Seq, Success: false, Command: request.Command, Message: text})
+}
+
+func (s *Server) event(name string, body interface{}) {
+	s.send(&event{Type: "event", Event: name, Body: body})
+}
+
+func (s *Server) send(m interface{}) {
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+
+	s.seq++
+	switch v := m.(type) {
+	case *response:
+		v.Seq = s.seq
+	case *event:
+		v.Seq = s.seq
+	}
+	writeMessage(s.out, m)
+}
+
+type output struct {
+	server   *Server
+	category string
+}
+
+func (o *output) Write(p []byte) (int, error) {
+	o.server.event("output", map[string]interface{}{"category": o.category, "output": string(p)})
+	return len(p), nil
+}
@@ -0,0 +1,203 @@
+package debugger
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/environment"
+	"github.com/iCiaran/golox/interpreter"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/parser"
+	"github.com/iCiaran/golox/scanner"
+)
+
+const help = `Commands:
+  break LINE (b)      set a breakpoint
+  delete LINE (d)     remove a breakpoint
+  step (s)            run to the next statement, entering calls
+  next (n)            run to the next statement in this function
+  finish (f)          run until the current function returns
+  continue (c)        run until the next breakpoint
+  backtrace (bt)      show the call stack
+  frame N             select a frame for print and vars
+  print EXPR (p)      evaluate an expression in the selected frame
+  vars                show the local variables of the selected frame
+  globals             show the global variables
+  list (l)            show the source around the current line
+  quit (q)            stop the script`
+
+type Debugger struct {
+	*Stepper
+	mu     sync.Mutex
+	input  *bufio.Reader
+	out    io.Writer
+	source []string
+	frame  int
+}
+
+func New(source string, input io.Reader, out io.Writer) *Debugger {
+	return &Debugger{
+		Stepper: NewStepper(),
+		input:   bufio.NewReader(input),
+		out:     out,
+		source:  strings.Split(source, "\n"),
+	}
+}
+
+func (d *Debugger) Statement(in *interpreter.Interpreter, stmt ast.Stmt, line int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.ShouldStop(line, in.Depth()) {
+		return
+	}
+
+	d.frame = 0
+	d.list(line, 0)
+	d.prompt(in, line)
+}
+
+func (d *Debugger) prompt(in *interpreter.Interpreter, line int) {
+	for {
+		fmt.Fprint(d.out, "(debug) ")
+		text, err := d.input.ReadString('\n')
+		if err != nil && text == "" {
+			fmt.Fprintln(d.out)
+			d.Mode = Run
+			d.Breakpoints = make(map[int]bool)
+			return
+		}
+
+		command, argument := split(text)
+		switch command {
+		case "":
+		case "s", "step":
+			d.Mode = StepIn
+			return
+		case "n", "next":
+			d.Mode = StepOver
+			return
+		case "f", "finish":
+			d.Mode = StepOut
+			return
+		case "c", "continue":
+			d.Mode = Run
+			return
+		case "b", "break":
+			if n, ok := d.lineArgument(argument); ok {
+				d.Breakpoints[n] = true
+				fmt.Fprintf(d.out, "Breakpoint set at line %d.\n", n)
+			}
+		case "d", "delete":
+			if n, ok := d.lineArgument(argument); ok {
+				delete(d.Breakpoints, n)
+				fmt.Fprintf(d.out, "Breakpoint removed from line %d.\n", n)
+			}
+		case "bt", "backtrace":
+			for n, frame := range in.StackTrace(line) {
+				marker := " "
+				if n == d.frame {
+					marker = "*"
+				}
+				fmt.Fprintf(d.out, "%s#%d %s at line %d\n", marker, n, frame.Name, frame.Line)
+			}
+		case "frame":
+			frames := in.StackTrace(line)
+			n, err := strconv.Atoi(argument)
+			if err != nil || n < 0 || n >= len(frames) {
+				fmt.Fprintf(d.out, "Frame must be between 0 and %d.\n", len(frames)-1)
+				break
+			}
+			d.frame = n
+			fmt.Fprintf(d.out, "#%d %s at line %d\n", n, frames[n].Name, frames[n].Line)
+		case "p", "print":
+			d.print(in, in.StackTrace(line)[d.frame].Environment, argument)
+		case "vars":
+			for env := in.StackTrace(line)[d.frame].Environment; env != nil && env != in.Globals(); env = env.Enclosing() {
+				d.variables(env.Values())
+			}
+		case "globals":
+			d.variables(in.Globals().Values())
+		case "l", "list":
+			d.list(in.StackTrace(line)[d.frame].Line, 5)
+		case "q", "quit":
+			panic(&interpreter.ExitError{Code: 0})
+		case "h", "help":
+			fmt.Fprintln(d.out, help)
+		default:
+			fmt.Fprintf(d.out, "Unknown command '%s'. Type 'help' for a list of commands.\n", command)
+		}
+	}
+}
+
+func (d *Debugger) print(in *interpreter.Interpreter, env *environment.Environment, source string) {
+	reporter := loxerror.NewReporter(d.out)
+	tokens := scanner.New(source, reporter).ScanTokens()
+	if reporter.HadError() {
+		return
+	}
+
+	expr := parser.NewParser(tokens, reporter).ParseExpression()
+	if reporter.HadError() {
+		return
+	}
+
+	value, err := in.Evaluate(expr, env)
+	if err != nil {
+		fmt.Fprintf(d.out, "Error: %v\n", err)
+		return
+	}
+	fmt.Fprintln(d.out, interpreter.Stringify(value))
+}
+
+func (d *Debugger) variables(values map[string]interface{}) {
+	names := make([]string, 0, len(values))
+	for name, value := range values {
+		if !interpreter.IsBuiltin(value) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(d.out, "%s = %s\n", name, interpreter.Stringify(values[name]))
+	}
+}
+
+func (d *Debugger) list(line, context int) {
+	for n := line - context; n <= line+context; n++ {
+		if n < 1 || n > len(d.source) {
+			continue
+		}
+		marker := "  "
+		if n == line {
+			marker = "->"
+		} else if d.Breakpoints[n] {
+			marker = " *"
+		}
+		fmt.Fprintf(d.out, "%s %4d  %s\n", marker, n, d.source[n-1])
+	}
+}
+
+func (d *Debugger) lineArgument(argument string) (int, bool) {
+	n, err := strconv.Atoi(argument)
+	if err != nil || n < 1 {
+		fmt.Fprintf(d.out, "Invalid line number '%s'.\n", argument)
+		return 0, false
+	}
+	return n, true
+}
+
+func split(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	if n := strings.IndexAny(text, " \t"); n >= 0 {
+		return text[:n], strings.TrimSpace(text[n+1:])
+	}
+	return text, ""
+}
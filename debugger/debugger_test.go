@@ -0,0 +1,81 @@
+package debugger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/iCiaran/golox/interpreter"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/parser"
+	"github.com/iCiaran/golox/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+const script = `fun add(a, b) {
+  var sum = a + b;
+  return sum;
+}
+var total = 0;
+for (var i = 0; i < 3; i++) {
+  total = add(total, i);
+}
+print total;`
+
+func TestDebugger(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		commands string
+		want     []string
+	}{
+		{
+			commands: "s\ns\ns\ns\ns\n",
+			want:     []string{"->    1  fun add", "->    5  var total", "->    6  for", "->    7    total", "->    2    var sum", "->    3    return sum"},
+		},
+		{
+			commands: "b 3\nc\nbt\nvars\np a * 10\np nope\nframe 1\nvars\nq\n",
+			want: []string{
+				"Breakpoint set at line 3.",
+				"*#0 <fn add> at line 3\n #1 <script> at line 7\n",
+				"a = 0\nb = 0\nsum = 0\n",
+				"(debug) 0\n",
+				"Error: Undefined variable 'nope'.",
+				"#1 <script> at line 7\n(debug) i = 0\n",
+			},
+		},
+		{
+			commands: "b 7\nc\nn\nn\nf\nc\nc\n",
+			want:     []string{"->    7    total", "->    6  for", "->    7    total"},
+		},
+		{
+			commands: "b 2\nc\nf\np total\nc\nglobals\nc\n",
+			want:     []string{"->    2    var sum", "->    6  for", "(debug) 0\n", "add = <fn add>\nargs = []\ntotal = 0\n"},
+		},
+		{
+			commands: "bogus\nb x\n",
+			want:     []string{"Unknown command 'bogus'.", "Invalid line number 'x'."},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			var out bytes.Buffer
+			reporter := loxerror.NewReporter(ioutil.Discard)
+			statements := parser.NewParser(scanner.New(script, reporter).ScanTokens(), reporter).Parse()
+
+			in := interpreter.NewInterpreter()
+			in.SetStdout(&out)
+			in.SetErrorOutput(ioutil.Discard)
+			in.SetDebugger(New(script, strings.NewReader(test.commands), &out))
+			in.InterpretContext(context.Background(), statements)
+
+			for _, want := range test.want {
+				assert.Contains(out.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package debugger
+
+type Mode int
+
+const (
+	StepIn Mode = iota
+	StepOver
+	StepOut
+	Run
+)
+
+type Stepper struct {
+	Breakpoints map[int]bool
+	Mode        Mode
+	stopLine    int
+	stopDepth   int
+	lastLine    int
+	lastDepth   int
+}
+
+func NewStepper() *Stepper {
+	return &Stepper{Breakpoints: make(map[int]bool)}
+}
+
+func (s *Stepper) ShouldStop(line, depth int) bool {
+	stop := s.AtBreakpoint(line, depth)
+	if !stop {
+		switch s.Mode {
+		case StepIn:
+			stop = line != s.stopLine || depth != s.stopDepth
+		case StepOver:
+			stop = depth < s.stopDepth || (depth == s.stopDepth && line != s.stopLine)
+		case StepOut:
+			stop = depth < s.stopDepth
+		}
+	}
+
+	s.lastLine, s.lastDepth = line, depth
+	if stop {
+		s.stopLine, s.stopDepth = line, depth
+	}
+	return stop
+}
+
+func (s *Stepper) AtBreakpoint(line, depth int) bool {
+	return s.Breakpoints[line] && (line != s.lastLine || depth != s.lastDepth)
+}
@@ -2,23 +2,32 @@ package environment
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/iCiaran/golox/loxerror"
 	"github.com/iCiaran/golox/token"
 )
 
 type Environment struct {
+	mu        sync.RWMutex
 	values    map[string]interface{}
 	enclosing *Environment
 }
 
 func NewEnvironment(enclosing *Environment) *Environment {
-	return &Environment{make(map[string]interface{}, 0), enclosing}
+	return &Environment{values: make(map[string]interface{}, 0), enclosing: enclosing}
 }
 
 func (e *Environment) Assign(name *token.Token, value interface{}) {
-	if _, ok := e.values[name.Lexeme]; ok {
+	e.mu.Lock()
+	_, ok := e.values[name.Lexeme]
+	if ok {
 		e.values[name.Lexeme] = value
+	}
+	e.mu.Unlock()
+
+	if ok {
+		return
 	} else if e.enclosing != nil {
 		e.enclosing.Assign(name, value)
 	} else {
@@ -27,11 +36,17 @@ func (e *Environment) Assign(name *token.Token, value interface{}) {
 }
 
 func (e *Environment) Define(name string, value interface{}) {
+	e.mu.Lock()
 	e.values[name] = value
+	e.mu.Unlock()
 }
 
 func (e *Environment) Get(name *token.Token) interface{} {
-	if val, ok := e.values[name.Lexeme]; ok {
+	e.mu.RLock()
+	val, ok := e.values[name.Lexeme]
+	e.mu.RUnlock()
+
+	if ok {
 		return val
 	}
 
@@ -42,3 +57,18 @@ func (e *Environment) Get(name *token.Token) interface{} {
 	loxerror.RuntimeError(name, fmt.Sprintf("Undefined variable '%s'.", name.Lexeme))
 	return nil
 }
+
+func (e *Environment) Enclosing() *Environment {
+	return e.enclosing
+}
+
+func (e *Environment) Values() map[string]interface{} {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	values := make(map[string]interface{}, len(e.values))
+	for name, value := range e.values {
+		values[name] = value
+	}
+	return values
+}
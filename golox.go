@@ -2,75 +2,254 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"regexp"
 
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/coverage"
+	"github.com/iCiaran/golox/dap"
+	"github.com/iCiaran/golox/debugger"
 	"github.com/iCiaran/golox/interpreter"
 	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/loxtest"
 	"github.com/iCiaran/golox/parser"
+	"github.com/iCiaran/golox/profiler"
 	"github.com/iCiaran/golox/scanner"
+	"github.com/iCiaran/golox/tracer"
 )
 
 var (
-	in = interpreter.NewInterpreter()
+	maxDepth  = flag.Int("max-depth", interpreter.DefaultMaxDepth, "maximum call depth before a stack overflow, 0 for no limit")
+	fileRoot  = flag.String("fs-root", "", "restrict the io module to files under this directory")
+	trace     = flag.Bool("trace", false, "log every executed statement, call and return to stderr")
+	traceJSON = flag.Bool("trace-json", false, "write the trace as JSON Lines (implies -trace)")
+	profile   = flag.String("profile", "", "write a pprof profile of the script to this file and print a report to stderr")
+	coverOut  = flag.String("coverage", "", "write statement and branch coverage to this file as JSON, or as lcov or HTML for .info, .lcov or .html files")
 )
 
 func main() {
-	if len(os.Args) > 2 {
-		fmt.Println("Usage: golox [script]")
+	flag.Usage = usage
+	flag.Parse()
+
+	if (*trace || *traceJSON) && *profile != "" {
+		fmt.Fprintln(os.Stderr, "The -trace and -profile flags cannot be used together.")
 		os.Exit(64)
-	} else if len(os.Args) == 2 {
-		runFile(os.Args[1])
-	} else {
-		runPrompt()
+	}
+
+	in := interpreter.NewInterpreter()
+	setup(in)
+
+	switch subcommand(os.Args[1:], flag.Args()) {
+	case "dap":
+		server := dap.NewServer(os.Stdin, os.Stdout)
+		server.Setup = setup
+		if err := server.Serve(); err != nil {
+			log.Fatal(err)
+		}
+	case "test":
+		os.Exit(runTests(flag.Args()[1:]))
+	case "debug":
+		if flag.NArg() < 2 {
+			usage()
+			os.Exit(64)
+		}
+		in.SetArgs(flag.Args()[2:])
+		os.Exit(debugFile(in, flag.Arg(1)))
+	default:
+		if flag.NArg() == 0 {
+			runPrompt(in)
+			return
+		}
+		in.SetArgs(flag.Args()[1:])
+		os.Exit(runFile(in, flag.Arg(0)))
 	}
 }
 
-func runFile(path string) {
-	source, err := ioutil.ReadFile(path)
+// A script named like a subcommand can still be run by putting "--" before
+// it, since flag.Parse drops the "--" we look for it in the raw arguments.
+func subcommand(args []string, rest []string) string {
+	if len(rest) == 0 {
+		return ""
+	}
+	if n := len(args) - len(rest) - 1; n >= 0 && args[n] == "--" {
+		return ""
+	}
+	return rest[0]
+}
+
+func setup(in *interpreter.Interpreter) {
+	in.SetMaxDepth(*maxDepth)
+	in.SetFileRoot(*fileRoot)
+}
+
+func usage() {
+	fmt.Fprintln(flag.CommandLine.Output(), "Usage: golox [flags] [script [args...]]")
+	fmt.Fprintln(flag.CommandLine.Output(), "       golox [flags] debug script [args...]")
+	fmt.Fprintln(flag.CommandLine.Output(), "       golox [flags] test [-run regexp] [dir...]")
+	fmt.Fprintln(flag.CommandLine.Output(), "       golox dap")
+	fmt.Fprintln(flag.CommandLine.Output(), "       golox [flags] -- script [args...]   (run a script named debug, test or dap)")
+	flag.PrintDefaults()
+}
+
+func runFile(in *interpreter.Interpreter, path string) int {
+	text, err := ioutil.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(in.Reporter().Writer(), err)
+		return 66
+	}
+
+	source := string(text)
+	statements := parse(in.Reporter(), source)
+	if in.Reporter().HadError() {
+		return 65
+	}
+
+	if *trace || *traceJSON {
+		in.SetTracer(tracer.New(source, os.Stderr, *traceJSON))
+	}
+	var p *profiler.Profiler
+	if *profile != "" {
+		p = profiler.New(path)
+		in.SetTracer(p)
+	}
+	var c *coverage.Coverage
+	if *coverOut != "" {
+		c = coverage.New(path, source, statements)
+		in.SetCoverage(c)
+	}
+
+	err = in.Interpret(statements)
+	if p != nil {
+		p.Stop()
+		writeFile(*profile, p.WriteProfile)
+		p.WriteReport(os.Stderr)
+	}
+	if c != nil {
+		switch filepath.Ext(*coverOut) {
+		case ".html":
+			writeFile(*coverOut, c.WriteHTML)
+		case ".info", ".lcov":
+			writeFile(*coverOut, c.WriteLCOV)
+		default:
+			writeFile(*coverOut, c.WriteJSON)
+		}
+		fmt.Fprintln(os.Stderr, c.Summary())
+	}
+	return exitCode(in, err)
+}
+
+func writeFile(path string, write func(w io.Writer) error) {
+	f, err := os.Create(path)
+	if err == nil {
+		err = write(f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
 	if err != nil {
 		log.Fatal(err)
-		os.Exit(66)
 	}
-	run(string(source))
-	if loxerror.HadRuntimeError {
-		os.Exit(70)
+}
+
+func runTests(args []string) int {
+	flags := flag.NewFlagSet("test", flag.ExitOnError)
+	filter := flags.String("run", "", "only run tests whose names match this regular expression")
+	flags.Parse(args)
+
+	runner := &loxtest.Runner{Out: os.Stdout, Setup: setup}
+	if *filter != "" {
+		re, err := regexp.Compile(*filter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid -run pattern: %v.\n", err)
+			return 64
+		}
+		runner.Filter = re
 	}
-	if loxerror.HadError {
-		os.Exit(65)
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
 	}
+
+	passed, err := runner.Run(paths...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 66
+	}
+	if !passed {
+		return 1
+	}
+	return 0
 }
 
-func runPrompt() {
+func debugFile(in *interpreter.Interpreter, path string) int {
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(in.Reporter().Writer(), err)
+		return 66
+	}
+
 	reader := bufio.NewReader(os.Stdin)
+	in.SetStdin(reader)
+	in.SetDebugger(debugger.New(string(source), reader, os.Stdout))
+	return exitCode(in, run(in, string(source)))
+}
+
+func exitCode(in *interpreter.Interpreter, err error) int {
+	if exit, ok := err.(*interpreter.ExitError); ok {
+		return exit.Code
+	}
+	if err != nil || in.Reporter().HadRuntimeError() {
+		return 70
+	}
+	if in.Reporter().HadError() {
+		return 65
+	}
+	return 0
+}
+
+func runPrompt(in *interpreter.Interpreter) {
+	reader := bufio.NewReader(os.Stdin)
+	in.SetStdin(reader)
 	for {
 		fmt.Print("> ")
-		line, _ := reader.ReadString('\n')
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Println()
+			return
+		}
 		if len(line) > 1 && line[len(line)-2] != ';' {
 			line = line[:len(line)-1] + ";\n"
 		}
-		run(line)
-		loxerror.HadError = false
-		loxerror.HadRuntimeError = false
+		if exit, ok := run(in, line).(*interpreter.ExitError); ok {
+			os.Exit(exit.Code)
+		}
+		in.Reporter().Reset()
 	}
 }
 
-func run(source string) {
-	sc := scanner.New(source)
-	tokens := sc.ScanTokens()
-
-	if loxerror.HadError {
-		return
+func run(in *interpreter.Interpreter, source string) error {
+	statements := parse(in.Reporter(), source)
+	if in.Reporter().HadError() {
+		return nil
 	}
+	return in.Interpret(statements)
+}
 
-	pa := parser.NewParser(tokens)
-	st := pa.Parse()
+func parse(reporter *loxerror.Reporter, source string) []ast.Stmt {
+	sc := scanner.New(source, reporter)
+	tokens := sc.ScanTokens()
 
-	if loxerror.HadError {
-		return
+	if reporter.HadError() {
+		return nil
 	}
 
-	in.Interpret(st)
+	pa := parser.NewParser(tokens, reporter)
+	return pa.Parse()
 }
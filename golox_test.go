@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubcommand(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		args []string
+		rest []string
+		want string
+	}{
+		{args: []string{}, rest: []string{}, want: ""},
+		{args: []string{"test"}, rest: []string{"test"}, want: "test"},
+		{args: []string{"-max-depth", "5", "debug", "main.lox"}, rest: []string{"debug", "main.lox"}, want: "debug"},
+		{args: []string{"main.lox", "test"}, rest: []string{"main.lox", "test"}, want: "main.lox"},
+		{args: []string{"--", "test"}, rest: []string{"test"}, want: ""},
+		{args: []string{"-trace", "--", "dap", "a"}, rest: []string{"dap", "a"}, want: ""},
+		{args: []string{"--", "--", "test"}, rest: []string{"--", "test"}, want: ""},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			assert.Equal(test.want, subcommand(test.args, test.rest))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/iCiaran/golox/loxerror"
+)
+
+func assertNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	if len(arguments) < 1 || len(arguments) > 2 {
+		loxerror.RuntimeError(interpreter.callSite(), fmt.Sprintf("Expected 1 or 2 arguments but got %v.", len(arguments)))
+	}
+
+	if interpreter.isTruthy(arguments[0]) {
+		return nil
+	}
+	if len(arguments) == 2 {
+		loxerror.RuntimeError(interpreter.callSite(), "Assertion failed: "+stringify(arguments[1]))
+	}
+	loxerror.RuntimeError(interpreter.callSite(), "Assertion failed.")
+	return nil
+}
+
+func assertEqualNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	if !valuesEqual(arguments[0], arguments[1], nil) {
+		loxerror.RuntimeError(interpreter.callSite(), fmt.Sprintf("Expected %s but got %s.", stringify(arguments[1]), stringify(arguments[0])))
+	}
+	return nil
+}
+
+func assertThrowsNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	function, ok := arguments[0].(Callable)
+	if !ok {
+		loxerror.RuntimeError(interpreter.callSite(), "Argument must be a function.")
+	}
+	if arity := function.Arity(); arity > 0 {
+		loxerror.RuntimeError(interpreter.callSite(), fmt.Sprintf("Expected 0 arguments but function takes %v.", arity))
+	}
+
+	message, threw := interpreter.throws(function)
+	if !threw {
+		loxerror.RuntimeError(interpreter.callSite(), "Expected function to throw.")
+	}
+	return message
+}
+
+func (i *Interpreter) throws(function Callable) (message string, threw bool) {
+	call, depth := i.callSite(), len(i.frames)
+	defer func() {
+		if r := recover(); r != nil {
+			runtime, ok := r.(*loxerror.Runtime)
+			if !ok {
+				panic(r)
+			}
+			i.frames = i.frames[:depth]
+			message, threw = runtime.Message, true
+		}
+	}()
+
+	i.call(function, call, nil)
+	return "", false
+}
@@ -0,0 +1,71 @@
+package interpreter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssert(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  string
+		err   string
+	}{
+		{
+			input: `assert(1 < 2, "unreachable"); assertEqual([1, {"a": 2}], [1, {"a": 2}]); print "ok";`,
+			want:  "ok\n",
+		},
+		{
+			input: `assert(false);`,
+			err:   "Assertion failed.",
+		},
+		{
+			input: `assert(nil, "value was " + str(nil));`,
+			err:   "Assertion failed: value was nil",
+		},
+		{
+			input: `assertEqual(1 + 1, 3);`,
+			err:   "Expected 3 but got 2.",
+		},
+		{
+			input: `print assertThrows(fun () { return 1 + nil; }); print "after";`,
+			want:  "Operands must be two numbers or at least one string.\nafter\n",
+		},
+		{
+			input: `fun deep(n) { if (n == 0) return [][0]; return deep(n - 1); } print assertThrows(fun () { deep(5); });`,
+			want:  "List index out of range.\n",
+		},
+		{
+			input: `assertThrows(fun () {});`,
+			err:   "Expected function to throw.",
+		},
+		{
+			input: `fun f(a) { return a; } assertThrows(f);`,
+			err:   "Expected 0 arguments but function takes 1.",
+		},
+		{
+			input: `print assertThrows(str);`,
+			err:   "Expected 0 arguments but function takes 1.",
+		},
+		{
+			input: `print assertThrows(assert);`,
+			want:  "Expected 1 or 2 arguments but got 0.\n",
+		},
+		{
+			input: `assertThrows(1);`,
+			err:   "Argument must be a function.",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			out, err := run(t, test.input)
+			assert.Equal(test.err, errorMessage(err))
+			assert.Equal(test.want, out)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package interpreter
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/token"
+)
+
+const (
+	decimalPrecision = 20
+	maxExponent      = 1 << 20
+	maxShift         = 1 << 24
+)
+
+func toBigInt(value interface{}) *big.Int {
+	if n, ok := value.(int64); ok {
+		return big.NewInt(n)
+	}
+	return value.(*big.Int)
+}
+
+func toRat(value interface{}) *big.Rat {
+	switch n := value.(type) {
+	case int64:
+		return new(big.Rat).SetInt64(n)
+	case *big.Int:
+		return new(big.Rat).SetInt(n)
+	}
+	return value.(*big.Rat)
+}
+
+func exactRat(value interface{}) (*big.Rat, bool) {
+	if f, ok := value.(float64); ok {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(f), true
+	}
+	return toRat(value), true
+}
+
+func (i *Interpreter) bigIntArithmetic(operator *token.Token, l, r *big.Int) interface{} {
+	result := new(big.Int)
+
+	switch operator.Type {
+	case token.PLUS:
+		result.Add(l, r)
+	case token.MINUS:
+		result.Sub(l, r)
+	case token.STAR:
+		result.Mul(l, r)
+	case token.SLASH:
+		i.checkBigDivisor(operator, r)
+		return i.decimalArithmetic(operator, new(big.Rat).SetInt(l), new(big.Rat).SetInt(r))
+	case token.TILDE_SLASH:
+		i.checkBigDivisor(operator, r)
+		result.Quo(l, r)
+	case token.PERCENT:
+		i.checkBigDivisor(operator, r)
+		result.Rem(l, r)
+	case token.STAR_STAR:
+		if r.Sign() < 0 {
+			return i.decimalArithmetic(operator, new(big.Rat).SetInt(l), new(big.Rat).SetInt(r))
+		}
+		i.checkExponent(operator, l.BitLen(), r)
+		result.Exp(l, r, nil)
+	}
+
+	i.allocate((result.BitLen() + 7) / 8)
+	return result
+}
+
+func (i *Interpreter) bigIntBitwise(operator *token.Token, l, r *big.Int) interface{} {
+	result := new(big.Int)
+
+	switch operator.Type {
+	case token.AMPERSAND:
+		result.And(l, r)
+	case token.PIPE:
+		result.Or(l, r)
+	case token.CARET:
+		result.Xor(l, r)
+	case token.LESS_LESS:
+		result.Lsh(l, i.checkBigShift(operator, r))
+	case token.GREATER_GREATER:
+		result.Rsh(l, i.checkBigShift(operator, r))
+	}
+
+	i.allocate((result.BitLen() + 7) / 8)
+	return result
+}
+
+func (i *Interpreter) decimalArithmetic(operator *token.Token, l, r *big.Rat) interface{} {
+	result := new(big.Rat)
+
+	switch operator.Type {
+	case token.PLUS:
+		result.Add(l, r)
+	case token.MINUS:
+		result.Sub(l, r)
+	case token.STAR:
+		result.Mul(l, r)
+	case token.SLASH:
+		i.checkBigDivisor(operator, r.Num())
+		result.Quo(l, r)
+	case token.TILDE_SLASH:
+		i.checkBigDivisor(operator, r.Num())
+		result.SetInt(truncRat(new(big.Rat).Quo(l, r)))
+	case token.PERCENT:
+		i.checkBigDivisor(operator, r.Num())
+		quotient := new(big.Rat).SetInt(truncRat(new(big.Rat).Quo(l, r)))
+		result.Sub(l, quotient.Mul(quotient, r))
+	case token.STAR_STAR:
+		if !r.IsInt() {
+			loxerror.RuntimeError(operator, "Decimal exponents must be integers.")
+		}
+		exponent := new(big.Int).Abs(r.Num())
+		i.checkExponent(operator, l.Num().BitLen()+l.Denom().BitLen(), exponent)
+		if r.Sign() < 0 {
+			i.checkBigDivisor(operator, l.Num())
+		}
+
+		num := new(big.Int).Exp(l.Num(), exponent, nil)
+		denom := new(big.Int).Exp(l.Denom(), exponent, nil)
+		result.SetFrac(num, denom)
+		if r.Sign() < 0 {
+			result.Inv(result)
+		}
+	}
+
+	i.allocate((result.Num().BitLen() + result.Denom().BitLen() + 7) / 8)
+	return result
+}
+
+func truncRat(r *big.Rat) *big.Int {
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+func (i *Interpreter) checkBigDivisor(operator *token.Token, divisor *big.Int) {
+	if divisor.Sign() == 0 {
+		loxerror.RuntimeError(operator, "Division by zero.")
+	}
+}
+
+func (i *Interpreter) checkExponent(operator *token.Token, baseBits int, exponent *big.Int) {
+	if !exponent.IsInt64() || exponent.Int64() > maxExponent {
+		loxerror.RuntimeError(operator, "Exponent too large.")
+	}
+	i.allocate(baseBits * int(exponent.Int64()) / 8)
+}
+
+func (i *Interpreter) checkBigShift(operator *token.Token, count *big.Int) uint {
+	if count.Sign() < 0 {
+		loxerror.RuntimeError(operator, "Shift count must not be negative.")
+	}
+	if !count.IsInt64() || count.Int64() > maxShift {
+		loxerror.RuntimeError(operator, "Shift count too large.")
+	}
+	return uint(count.Int64())
+}
+
+func formatDecimal(r *big.Rat) string {
+	digits, ok := decimalDigits(r.Denom())
+	if ok {
+		return r.FloatString(digits)
+	}
+
+	s := strings.TrimRight(r.FloatString(decimalPrecision), "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+func decimalDigits(denom *big.Int) (int, bool) {
+	d := new(big.Int).Set(denom)
+	twos, fives := 0, 0
+	two, five := big.NewInt(2), big.NewInt(5)
+	m := new(big.Int)
+
+	for d.Cmp(big.NewInt(1)) != 0 {
+		if m.Mod(d, two).Sign() == 0 {
+			d.Quo(d, two)
+			twos++
+		} else if m.Mod(d, five).Sign() == 0 {
+			d.Quo(d, five)
+			fives++
+		} else {
+			return 0, false
+		}
+	}
+
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
+
+func floatToRat(f float64) (*big.Rat, bool) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+}
@@ -0,0 +1,149 @@
+package interpreter
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/token"
+)
+
+type channel struct {
+	ch chan interface{}
+}
+
+func chanNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	if len(arguments) > 1 {
+		loxerror.RuntimeError(interpreter.callSite(), fmt.Sprintf("Expected 0 or 1 arguments but got %v.", len(arguments)))
+	}
+
+	var size int64
+	if len(arguments) == 1 {
+		size = interpreter.intArgument(arguments[0])
+		if size < 0 {
+			loxerror.RuntimeError(interpreter.callSite(), "Channel size must not be negative.")
+		}
+	}
+
+	interpreter.allocate(slotSize * int(size))
+	return &channel{make(chan interface{}, size)}
+}
+
+func (c *channel) get(interpreter *Interpreter, name *token.Token) interface{} {
+	switch name.Lexeme {
+	case "send":
+		return &native{"channel.send", 1, c.send}
+	case "recv":
+		return &native{"channel.recv", 0, c.recv}
+	case "trySend":
+		return &native{"channel.trySend", 1, c.trySend}
+	case "tryRecv":
+		return &native{"channel.tryRecv", 0, c.tryRecv}
+	case "close":
+		return &native{"channel.close", 0, c.close}
+	}
+
+	loxerror.RuntimeError(name, fmt.Sprintf("Undefined property '%s' on channel.", name.Lexeme))
+	return nil
+}
+
+func (c *channel) send(interpreter *Interpreter, arguments []interface{}) interface{} {
+	defer checkClosed(interpreter, "Cannot send on a closed channel.")
+
+	select {
+	case c.ch <- arguments[0]:
+	case <-interpreter.ctx.Done():
+		panic(&CancelledError{interpreter.ctx.Err()})
+	}
+	return nil
+}
+
+func (c *channel) recv(interpreter *Interpreter, arguments []interface{}) interface{} {
+	select {
+	case value := <-c.ch:
+		return value
+	case <-interpreter.ctx.Done():
+		panic(&CancelledError{interpreter.ctx.Err()})
+	}
+}
+
+func (c *channel) trySend(interpreter *Interpreter, arguments []interface{}) interface{} {
+	defer checkClosed(interpreter, "Cannot send on a closed channel.")
+
+	select {
+	case c.ch <- arguments[0]:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *channel) tryRecv(interpreter *Interpreter, arguments []interface{}) interface{} {
+	select {
+	case value := <-c.ch:
+		return value
+	default:
+		return nil
+	}
+}
+
+func (c *channel) close(interpreter *Interpreter, arguments []interface{}) interface{} {
+	defer checkClosed(interpreter, "Channel is already closed.")
+
+	close(c.ch)
+	return nil
+}
+
+func (c *channel) String() string {
+	return fmt.Sprintf("<channel %d>", cap(c.ch))
+}
+
+func checkClosed(interpreter *Interpreter, message string) {
+	if r := recover(); r != nil {
+		if _, ok := r.(runtime.Error); ok {
+			loxerror.RuntimeError(interpreter.callSite(), message)
+		}
+		panic(r)
+	}
+}
+
+func selectNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	if len(arguments) < 1 || len(arguments) > 2 {
+		loxerror.RuntimeError(interpreter.callSite(), fmt.Sprintf("Expected 1 or 2 arguments but got %v.", len(arguments)))
+	}
+
+	channels := interpreter.listArgument(arguments[0]).Elements()
+	cases := make([]reflect.SelectCase, 0, len(channels)+2)
+	for _, value := range channels {
+		c, ok := value.(*channel)
+		if !ok {
+			loxerror.RuntimeError(interpreter.callSite(), "Can only select on channels.")
+		}
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.ch)})
+	}
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(interpreter.ctx.Done())})
+
+	if len(arguments) == 2 && arguments[1] != nil {
+		timeout := interpreter.intArgument(arguments[1])
+		timer := time.NewTimer(time.Duration(timeout) * time.Millisecond)
+		defer timer.Stop()
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)})
+	}
+
+	chosen, value, _ := reflect.Select(cases)
+	switch {
+	case chosen == len(channels):
+		panic(&CancelledError{interpreter.ctx.Err()})
+	case chosen > len(channels):
+		return nil
+	}
+
+	interpreter.allocate(2 * slotSize)
+	var received interface{}
+	if value.IsValid() {
+		received = value.Interface()
+	}
+	return NewList([]interface{}{channels[chosen], received})
+}
@@ -7,7 +7,7 @@ import (
 type Clock struct{}
 
 func (c *Clock) Call(interpreter *Interpreter, arguments []interface{}) interface{} {
-	return float64(time.Now().Unix())
+	return float64(time.Now().UnixNano()) / float64(time.Second)
 }
 
 func (c *Clock) Arity() int {
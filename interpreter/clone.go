@@ -0,0 +1,125 @@
+package interpreter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iCiaran/golox/environment"
+	"github.com/iCiaran/golox/loxerror"
+)
+
+type cloner struct {
+	environments map[*environment.Environment]*environment.Environment
+	values       map[interface{}]interface{}
+}
+
+type CloneError struct {
+	Name string
+	Kind string
+}
+
+func (e *CloneError) Error() string {
+	return fmt.Sprintf("Cannot clone '%s': %s cannot be shared between interpreters.", e.Name, e.Kind)
+}
+
+func (i *Interpreter) Clone() (clone *Interpreter, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			cloneErr, ok := r.(*CloneError)
+			if !ok {
+				panic(r)
+			}
+			clone, err = nil, cloneErr
+		}
+	}()
+
+	c := &cloner{
+		environments: make(map[*environment.Environment]*environment.Environment),
+		values:       make(map[interface{}]interface{}),
+	}
+
+	clone = &Interpreter{
+		noScript: i.noScript,
+		maxDepth: i.maxDepth,
+		ctx:      context.Background(),
+		limits:   i.limits,
+		budget:   &budget{},
+		fileRoot: i.fileRoot,
+		stdio:    &stdio{in: i.stdio.input(), out: i.stdio.out},
+		reporter: loxerror.NewReporter(i.reporter.Writer()),
+	}
+	clone.globals = c.environment(i.globals)
+	clone.environment = clone.globals
+	return clone, nil
+}
+
+func (c *cloner) environment(env *environment.Environment) *environment.Environment {
+	if env == nil {
+		return nil
+	}
+	if cloned, ok := c.environments[env]; ok {
+		return cloned
+	}
+
+	cloned := environment.NewEnvironment(c.environment(env.Enclosing()))
+	c.environments[env] = cloned
+	for name, value := range env.Values() {
+		c.define(cloned, name, value)
+	}
+	return cloned
+}
+
+func (c *cloner) define(env *environment.Environment, name string, value interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			if err, ok := r.(*CloneError); ok {
+				err.Name = name
+			}
+			panic(r)
+		}
+	}()
+
+	env.Define(name, c.value(value))
+}
+
+func (c *cloner) value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *Function:
+		if cloned, ok := c.values[v]; ok {
+			return cloned
+		}
+		cloned := &Function{declaration: v.declaration}
+		c.values[v] = cloned
+		cloned.environment = c.environment(v.environment)
+		return cloned
+	case *List:
+		if cloned, ok := c.values[v]; ok {
+			return cloned
+		}
+		cloned := NewList(nil)
+		c.values[v] = cloned
+		for _, element := range v.Elements() {
+			cloned.elements = append(cloned.elements, c.value(element))
+		}
+		return cloned
+	case *Map:
+		if cloned, ok := c.values[v]; ok {
+			return cloned
+		}
+		cloned := NewMap()
+		c.values[v] = cloned
+		for _, item := range v.items() {
+			cloned.set(c.value(item.normalised), c.value(item.key), c.value(item.value))
+		}
+		return cloned
+	case *generator:
+		panic(&CloneError{Kind: "generators"})
+	case *channel:
+		panic(&CloneError{Kind: "channels"})
+	case *task:
+		panic(&CloneError{Kind: "tasks"})
+	case *file:
+		panic(&CloneError{Kind: "open files"})
+	}
+	return value
+}
@@ -0,0 +1,120 @@
+package interpreter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const baseline = `
+var counter = 0;
+var seen = [];
+var config = {"name": "base"};
+fun record(value) { counter++; push(seen, value); return counter; }
+fun makeCounter() { var n = 0; return () => ++n; }
+var next = makeCounter();
+`
+
+func TestClone(t *testing.T) {
+	assert := assert.New(t)
+
+	base := NewInterpreter()
+	assert.Nil(base.InterpretContext(context.Background(), parse(baseline)))
+
+	var out bytes.Buffer
+	clone, err := base.Clone()
+	assert.Nil(err)
+	clone.SetStdout(&out)
+	err = clone.InterpretContext(context.Background(), parse(`record("a"); config["name"] = "clone"; print next(); print next(); print counter; print seen; print config;`))
+	assert.Nil(err)
+	assert.Equal("1\n2\n1\n[\"a\"]\n{\"name\": \"clone\"}\n", out.String())
+
+	out.Reset()
+	base.SetStdout(&out)
+	err = base.InterpretContext(context.Background(), parse("print next(); print counter; print seen; print config;"))
+	assert.Nil(err)
+	assert.Equal("1\n0\n[]\n{\"name\": \"base\"}\n", out.String())
+}
+
+func TestConcurrentInterpreters(t *testing.T) {
+	assert := assert.New(t)
+
+	base := NewInterpreter()
+	assert.Nil(base.InterpretContext(context.Background(), parse(baseline)))
+
+	var wg sync.WaitGroup
+	outputs := make([]string, 50)
+	errs := make([]error, 50)
+
+	for n := range outputs {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+
+			var out, stderr bytes.Buffer
+			in, err := base.Clone()
+			if err != nil {
+				errs[n] = err
+				return
+			}
+			in.SetStdout(&out)
+			in.SetErrorOutput(&stderr)
+
+			source := fmt.Sprintf("for (var i = 0; i < %d; i++) record(i); print counter; print len(seen); print next(); print str(nope);", n)
+			errs[n] = in.InterpretContext(context.Background(), parse(source))
+			outputs[n] = out.String() + stderr.String()
+		}(n)
+	}
+	wg.Wait()
+
+	for n, output := range outputs {
+		assert.Error(errs[n])
+		assert.True(strings.HasPrefix(output, fmt.Sprintf("%d\n%d\n1\n[1] Error : Undefined variable 'nope'.\n", n, n)), output)
+	}
+}
+
+func TestCloneRejectsSharedState(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{
+			input: "fun gen() { yield 1; } var g = gen();",
+			want:  &CloneError{"g", "generators"},
+		},
+		{
+			input: "var queues = {\"jobs\": [chan(1)]};",
+			want:  &CloneError{"queues", "channels"},
+		},
+		{
+			input: "var t = spawn(() => 1); t.wait();",
+			want:  &CloneError{"t", "tasks"},
+		},
+		{
+			input: "fun make() { var c = chan(); return () => c; } var get = make();",
+			want:  &CloneError{"get", "channels"},
+		},
+		{
+			input: "fun gen() { yield 1; } var makeGen = gen;",
+			want:  nil,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			base := NewInterpreter()
+			assert.Nil(base.InterpretContext(context.Background(), parse(test.input)))
+
+			clone, err := base.Clone()
+			assert.Equal(test.want, err)
+			assert.Equal(test.want == nil, clone != nil)
+		})
+	}
+}
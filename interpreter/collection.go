@@ -0,0 +1,342 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/token"
+)
+
+const slotSize = 16
+
+type List struct {
+	mu       sync.Mutex
+	elements []interface{}
+}
+
+func NewList(elements []interface{}) *List {
+	return &List{elements: elements}
+}
+
+func (l *List) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.elements)
+}
+
+func (l *List) Elements() []interface{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	values := make([]interface{}, len(l.elements))
+	copy(values, l.elements)
+	return values
+}
+
+func (l *List) get(n int64) (interface{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n < 0 || n >= int64(len(l.elements)) {
+		return nil, false
+	}
+	return l.elements[n], true
+}
+
+func (l *List) set(n int64, value interface{}) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n < 0 || n >= int64(len(l.elements)) {
+		return false
+	}
+	l.elements[n] = value
+	return true
+}
+
+func (l *List) push(value interface{}) {
+	l.mu.Lock()
+	l.elements = append(l.elements, value)
+	l.mu.Unlock()
+}
+
+func (l *List) pop() (interface{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.elements) == 0 {
+		return nil, false
+	}
+	last := l.elements[len(l.elements)-1]
+	l.elements = l.elements[:len(l.elements)-1]
+	return last, true
+}
+
+type Map struct {
+	mu      sync.Mutex
+	order   []interface{}
+	entries map[interface{}]*mapEntry
+}
+
+type mapEntry struct {
+	key   interface{}
+	value interface{}
+}
+
+type mapItem struct {
+	normalised interface{}
+	mapEntry
+}
+
+type bigKey string
+
+func NewMap() *Map {
+	return &Map{order: make([]interface{}, 0), entries: make(map[interface{}]*mapEntry)}
+}
+
+func (m *Map) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.order)
+}
+
+func (m *Map) get(key interface{}) (interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry, ok := m.entries[key]; ok {
+		return entry.value, true
+	}
+	return nil, false
+}
+
+func (m *Map) set(normalised, key, value interface{}) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry, ok := m.entries[normalised]; ok {
+		entry.value = value
+		return false
+	}
+	m.order = append(m.order, normalised)
+	m.entries[normalised] = &mapEntry{key, value}
+	return true
+}
+
+func (m *Map) remove(key interface{}) (interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	delete(m.entries, key)
+	for n, k := range m.order {
+		if k == key {
+			m.order = append(m.order[:n], m.order[n+1:]...)
+			break
+		}
+	}
+	return entry.value, true
+}
+
+func (m *Map) Keys() []interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]interface{}, len(m.order))
+	for n, k := range m.order {
+		keys[n] = m.entries[k].key
+	}
+	return keys
+}
+
+func (m *Map) Entries() ([]interface{}, []interface{}) {
+	items := m.items()
+	keys := make([]interface{}, len(items))
+	values := make([]interface{}, len(items))
+	for n, item := range items {
+		keys[n], values[n] = item.key, item.value
+	}
+	return keys, values
+}
+
+func (m *Map) items() []mapItem {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	items := make([]mapItem, len(m.order))
+	for n, k := range m.order {
+		items[n] = mapItem{k, *m.entries[k]}
+	}
+	return items
+}
+
+func (i *Interpreter) VisitListExpr(expr ast.List) interface{} {
+	elements := make([]interface{}, len(expr.Elements))
+	for n, element := range expr.Elements {
+		elements[n] = i.evaluate(element)
+	}
+
+	i.allocate(slotSize * len(elements))
+	return NewList(elements)
+}
+
+func (i *Interpreter) VisitMapExpr(expr ast.Map) interface{} {
+	m := NewMap()
+	for n := range expr.Keys {
+		key := i.evaluate(expr.Keys[n])
+		value := i.evaluate(expr.Values[n])
+		i.mapSet(expr.Brace, m, key, value)
+	}
+	return m
+}
+
+func (i *Interpreter) VisitIndexExpr(expr ast.Index) interface{} {
+	object := i.evaluate(expr.Object)
+	index := i.evaluate(expr.Index)
+	return i.getIndex(expr.Bracket, object, index)
+}
+
+func (i *Interpreter) VisitSetIndexExpr(expr ast.SetIndex) interface{} {
+	object := i.evaluate(expr.Object)
+	index := i.evaluate(expr.Index)
+	value := i.evaluate(expr.Value)
+
+	i.setIndex(expr.Bracket, object, index, value)
+	return value
+}
+
+func (i *Interpreter) getIndex(bracket *token.Token, object, index interface{}) interface{} {
+	switch o := object.(type) {
+	case *List:
+		value, ok := o.get(i.listIndex(bracket, index))
+		if !ok {
+			loxerror.RuntimeError(bracket, "List index out of range.")
+		}
+		return value
+	case *Map:
+		value, _ := o.get(i.mapKey(bracket, index))
+		return value
+	}
+
+	loxerror.RuntimeError(bracket, "Only lists and maps can be indexed.")
+	return nil
+}
+
+func (i *Interpreter) setIndex(bracket *token.Token, object, index, value interface{}) {
+	switch o := object.(type) {
+	case *List:
+		if !o.set(i.listIndex(bracket, index), value) {
+			loxerror.RuntimeError(bracket, "List index out of range.")
+		}
+	case *Map:
+		i.mapSet(bracket, o, index, value)
+	default:
+		loxerror.RuntimeError(bracket, "Only lists and maps can be indexed.")
+	}
+}
+
+func (i *Interpreter) listIndex(bracket *token.Token, index interface{}) int64 {
+	n, ok := index.(int64)
+	if !ok {
+		loxerror.RuntimeError(bracket, "List index must be an integer.")
+	}
+	return n
+}
+
+func (i *Interpreter) mapSet(t *token.Token, m *Map, key, value interface{}) {
+	if m.set(i.mapKey(t, key), key, value) {
+		i.allocate(2 * slotSize)
+	}
+}
+
+func (i *Interpreter) mapKey(t *token.Token, key interface{}) interface{} {
+	switch k := key.(type) {
+	case nil, bool, string, int64, Callable, *channel, *task, *generator, *file, *regex, *module:
+		return key
+	case float64:
+		if math.IsNaN(k) {
+			loxerror.RuntimeError(t, "Map keys cannot be NaN.")
+		}
+		if k == math.Trunc(k) && math.Abs(k) < math.MaxInt64 {
+			return int64(k)
+		}
+		return k
+	case *big.Int:
+		if k.IsInt64() {
+			return k.Int64()
+		}
+		return bigKey(k.String())
+	case *big.Rat:
+		if k.IsInt() {
+			return i.mapKey(t, k.Num())
+		}
+		if f, exact := k.Float64(); exact {
+			return f
+		}
+		return bigKey(k.RatString())
+	case *List:
+		loxerror.RuntimeError(t, "Lists cannot be used as map keys.")
+	case *Map:
+		loxerror.RuntimeError(t, "Maps cannot be used as map keys.")
+	}
+
+	loxerror.RuntimeError(t, fmt.Sprintf("%s cannot be used as a map key.", Stringify(key)))
+	return nil
+}
+
+type visit struct {
+	a, b interface{}
+}
+
+func valuesEqual(a, b interface{}, visiting map[visit]bool) bool {
+	if isNumber(a) && isNumber(b) {
+		return numbersEqual(a, b)
+	}
+
+	switch l := a.(type) {
+	case *List:
+		r, ok := b.(*List)
+		if !ok {
+			return false
+		}
+		left, right := l.Elements(), r.Elements()
+		if len(left) != len(right) {
+			return false
+		}
+		if l == r || visiting[visit{l, r}] {
+			return true
+		}
+		if visiting == nil {
+			visiting = make(map[visit]bool)
+		}
+		visiting[visit{l, r}] = true
+
+		for n := range left {
+			if !valuesEqual(left[n], right[n], visiting) {
+				return false
+			}
+		}
+		return true
+	case *Map:
+		r, ok := b.(*Map)
+		if !ok || l.Len() != r.Len() {
+			return false
+		}
+		if l == r || visiting[visit{l, r}] {
+			return true
+		}
+		if visiting == nil {
+			visiting = make(map[visit]bool)
+		}
+		visiting[visit{l, r}] = true
+
+		for _, item := range l.items() {
+			value, ok := r.get(item.normalised)
+			if !ok || !valuesEqual(item.value, value, visiting) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return a == b
+}
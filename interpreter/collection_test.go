@@ -0,0 +1,96 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValuesEqual(t *testing.T) {
+	assert := assert.New(t)
+
+	cyclic := func() *List {
+		l := NewList([]interface{}{int64(1)})
+		l.elements = append(l.elements, l)
+		return l
+	}
+	m := func(pairs ...interface{}) *Map {
+		m := NewMap()
+		for n := 0; n < len(pairs); n += 2 {
+			m.set(pairs[n], pairs[n], pairs[n+1])
+		}
+		return m
+	}
+
+	tests := []struct {
+		a, b interface{}
+		want bool
+	}{
+		{a: nil, b: nil, want: true},
+		{a: nil, b: false, want: false},
+		{a: int64(1), b: 1.0, want: true},
+		{a: big.NewInt(1), b: 1.0, want: true},
+		{a: big.NewRat(1, 10), b: 0.1, want: false},
+		{a: math.NaN(), b: math.NaN(), want: false},
+		{a: "a", b: "a", want: true},
+		{a: NewList([]interface{}{int64(1), "a"}), b: NewList([]interface{}{1.0, "a"}), want: true},
+		{a: NewList([]interface{}{int64(1)}), b: NewList([]interface{}{int64(2)}), want: false},
+		{a: NewList([]interface{}{}), b: NewMap(), want: false},
+		{a: m("a", int64(1), "b", int64(2)), b: m("b", int64(2), "a", int64(1)), want: true},
+		{a: m("a", int64(1)), b: m("a", int64(2)), want: false},
+		{a: cyclic(), b: cyclic(), want: true},
+		{a: &native{name: "f"}, b: &native{name: "f"}, want: false},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			assert.Equal(test.want, valuesEqual(test.a, test.b, nil))
+		})
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  string
+		err   string
+	}{
+		{
+			input: `var m = {1: "int", 1.0: "float", 1n: "bigint", 1m: "decimal"}; print m; print len(m);`,
+			want:  "{1: \"decimal\"}\n1\n",
+		},
+		{
+			input: `var c = chan(); var d = chan(); var m = {}; m[c] = "c"; m[d] = "d"; print m[c]; print m[d]; print len(m);`,
+			want:  "c\nd\n2\n",
+		},
+		{
+			input: `fun gen() { yield 1; } var g = gen(); var t = spawn(() => 1); var m = {g: 1, t: 2, regex.compile("a"): 3}; print m[g] + m[t]; print len(m);`,
+			want:  "3\n3\n",
+		},
+		{
+			input: `var m = {[1]: 1};`,
+			err:   "Lists cannot be used as map keys.",
+		},
+		{
+			input: `var m = {}; m[{}] = 1;`,
+			err:   "Maps cannot be used as map keys.",
+		},
+		{
+			input: `var m = {}; m[0.0 / 0.0] = 1;`,
+			err:   "Map keys cannot be NaN.",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			out, err := run(t, test.input)
+			assert.Equal(test.err, errorMessage(err))
+			assert.Equal(test.want, out)
+		})
+	}
+}
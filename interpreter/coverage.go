@@ -0,0 +1,15 @@
+package interpreter
+
+import (
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/token"
+)
+
+type Coverage interface {
+	Statement(interpreter *Interpreter, stmt ast.Stmt)
+	Branch(interpreter *Interpreter, keyword *token.Token, taken bool)
+}
+
+func (i *Interpreter) SetCoverage(coverage Coverage) {
+	i.coverage = coverage
+}
@@ -0,0 +1,115 @@
+package interpreter
+
+import (
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/environment"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/token"
+)
+
+type Debugger interface {
+	Statement(interpreter *Interpreter, stmt ast.Stmt, line int)
+}
+
+func (i *Interpreter) SetDebugger(debugger Debugger) {
+	i.debugger = debugger
+}
+
+func (i *Interpreter) Depth() int {
+	return len(i.frames)
+}
+
+func (i *Interpreter) Globals() *environment.Environment {
+	return i.globals
+}
+
+func (i *Interpreter) Evaluate(expr ast.Expr, env *environment.Environment) (value interface{}, err error) {
+	previous, debugger, tracer, coverage, depth := i.environment, i.debugger, i.tracer, i.coverage, len(i.frames)
+	defer func() {
+		i.environment, i.debugger, i.tracer, i.coverage, i.frames = previous, debugger, tracer, coverage, i.frames[:depth]
+		if r := recover(); r != nil {
+			runtime, ok := r.(*loxerror.Runtime)
+			if !ok {
+				panic(r)
+			}
+			err = runtime
+		}
+	}()
+
+	i.environment, i.debugger, i.tracer, i.coverage = env, nil, nil, nil
+	return i.evaluate(expr), nil
+}
+
+func IsBuiltin(value interface{}) bool {
+	switch value.(type) {
+	case *native, *Clock, *module, *disabledNative:
+		return true
+	}
+	return false
+}
+
+func (i *Interpreter) debugStatement(stmt ast.Stmt) {
+	if t := StatementToken(stmt); t != nil {
+		i.debugger.Statement(i, stmt, t.Line)
+	}
+}
+
+func StatementToken(stmt ast.Stmt) *token.Token {
+	switch s := stmt.(type) {
+	case *ast.Expression:
+		return expressionToken(s.Expr)
+	case *ast.ForIn:
+		return s.Name
+	case *ast.Function:
+		return s.Name
+	case *ast.If:
+		return s.Keyword
+	case *ast.Print:
+		return s.Keyword
+	case *ast.Return:
+		return s.Keyword
+	case *ast.Var:
+		return s.Name
+	case *ast.While:
+		return s.Keyword
+	case *ast.Yield:
+		return s.Keyword
+	}
+	return nil
+}
+
+func expressionToken(expr ast.Expr) *token.Token {
+	switch e := expr.(type) {
+	case *ast.Assign:
+		return e.Name
+	case *ast.Binary:
+		return expressionToken(e.Left)
+	case *ast.Call:
+		return expressionToken(e.Callee)
+	case *ast.Conditional:
+		return expressionToken(e.Condition)
+	case *ast.Get:
+		return expressionToken(e.Object)
+	case *ast.Grouping:
+		return expressionToken(e.Expression)
+	case *ast.Index:
+		return expressionToken(e.Object)
+	case *ast.Lambda:
+		return e.Keyword
+	case *ast.List:
+		return e.Bracket
+	case *ast.Logical:
+		return expressionToken(e.Left)
+	case *ast.Map:
+		return e.Brace
+	case *ast.SetIndex:
+		return expressionToken(e.Object)
+	case *ast.Unary:
+		return e.Operator
+	case *ast.Update:
+		return expressionToken(e.Target)
+	case *ast.Variable:
+		return e.Name
+	}
+	return nil
+}
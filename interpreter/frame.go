@@ -0,0 +1,72 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/iCiaran/golox/environment"
+	"github.com/iCiaran/golox/token"
+)
+
+const maxTraceFrames = 20
+
+type callFrame struct {
+	name        string
+	call        *token.Token
+	environment *environment.Environment
+}
+
+type Frame struct {
+	Name        string
+	Line        int
+	Environment *environment.Environment
+}
+
+func (i *Interpreter) pushFrame(callee Callable, call *token.Token) {
+	i.frames = append(i.frames, callFrame{callee.String(), call, i.environment})
+}
+
+func (i *Interpreter) popFrame() {
+	i.frames = i.frames[:len(i.frames)-1]
+}
+
+func (i *Interpreter) callSite() *token.Token {
+	return i.frames[len(i.frames)-1].call
+}
+
+func (i *Interpreter) StackTrace(line int) []Frame {
+	frames := make([]Frame, 0, len(i.frames)+1)
+
+	env := i.environment
+	for f := len(i.frames) - 1; f >= 0; f-- {
+		frames = append(frames, Frame{i.frames[f].name, line, env})
+		line = i.frames[f].call.Line
+		env = i.frames[f].environment
+	}
+	if i.noScript {
+		return frames
+	}
+	return append(frames, Frame{"<script>", line, env})
+}
+
+func (i *Interpreter) SetScriptFrame(enabled bool) {
+	i.noScript = !enabled
+}
+
+func (i *Interpreter) printTraceback(t *token.Token) {
+	frames := i.StackTrace(t.Line)
+	lines := make([]string, len(frames))
+	for n, frame := range frames {
+		lines[n] = fmt.Sprintf("[line %d] in %s", frame.Line, frame.Name)
+	}
+
+	if len(lines) > maxTraceFrames {
+		truncated := make([]string, 0, maxTraceFrames+1)
+		truncated = append(truncated, lines[:maxTraceFrames/2]...)
+		truncated = append(truncated, fmt.Sprintf("... %d more frames ...", len(lines)-maxTraceFrames))
+		lines = append(truncated, lines[len(lines)-maxTraceFrames/2:]...)
+	}
+
+	for _, l := range lines {
+		fmt.Fprintln(i.reporter.Writer(), l)
+	}
+}
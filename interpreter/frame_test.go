@@ -0,0 +1,151 @@
+package interpreter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func traceback(in *Interpreter, source string) (string, error) {
+	var errors bytes.Buffer
+	in.SetStdout(ioutil.Discard)
+	in.SetErrorOutput(&errors)
+	err := in.InterpretContext(context.Background(), parse(source))
+	return errors.String(), err
+}
+
+func TestTraceback(t *testing.T) {
+	assert := assert.New(t)
+
+	recurse := "fun f(n) {\n  if (n == 0) return -nil;\n  return f(n - 1);\n}\nf(%d);\n"
+	frames := func(n int) string {
+		return strings.Repeat("[line 3] in <fn f>\n", n)
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{
+			input: "fun f() {\n  return len(1);\n}\nf();\n",
+			want:  "[2] Error : Argument must be a string, list or map.\n[line 2] in <native len>\n[line 2] in <fn f>\n[line 4] in <script>\n",
+		},
+		{
+			input: "var g = (x) =>\n  len(x);\ng(1);\n",
+			want:  "[2] Error : Argument must be a string, list or map.\n[line 2] in <native len>\n[line 2] in <fn>\n[line 3] in <script>\n",
+		},
+		{
+			input: "fun f() {\n  return -nil;\n}\nfun g() {\n  f();\n}\ng();\n",
+			want:  "[2] Error : Operand must be a number.\n[line 2] in <fn f>\n[line 5] in <fn g>\n[line 7] in <script>\n",
+		},
+		{
+			input: "print -nil;\n",
+			want:  "[1] Error : Operand must be a number.\n[line 1] in <script>\n",
+		},
+		{
+			input: fmt.Sprintf(recurse, 18),
+			want:  "[2] Error : Operand must be a number.\n[line 2] in <fn f>\n" + frames(18) + "[line 5] in <script>\n",
+		},
+		{
+			input: fmt.Sprintf(recurse, 30),
+			want:  "[2] Error : Operand must be a number.\n[line 2] in <fn f>\n" + frames(9) + "... 12 more frames ...\n" + frames(9) + "[line 5] in <script>\n",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			out, err := traceback(NewInterpreter(), test.input)
+			assert.NotNil(err)
+			assert.Equal(test.want, out)
+		})
+	}
+}
+
+func TestFramesResetAfterError(t *testing.T) {
+	assert := assert.New(t)
+
+	in := NewInterpreter()
+	_, err := traceback(in, "fun f() { return -nil; } fun g() { return f(); } g();")
+	assert.NotNil(err)
+	assert.Empty(in.frames)
+
+	out, err := traceback(in, "fun h() { return -nil; } h();")
+	assert.NotNil(err)
+	assert.Equal("[1] Error : Operand must be a number.\n[line 1] in <fn h>\n[line 1] in <script>\n", out)
+	assert.Empty(in.frames)
+}
+
+func TestStackOverflow(t *testing.T) {
+	assert := assert.New(t)
+
+	recurse := "fun f(n) {\n  return f(n + 1);\n}\nf(0);\n"
+	frames := func(n int) string {
+		return strings.Repeat("[line 2] in <fn f>\n", n)
+	}
+
+	tests := []struct {
+		input    string
+		maxDepth int
+		want     string
+	}{
+		{
+			input:    recurse,
+			maxDepth: 19,
+			want:     "[2] Error : Stack overflow.\n" + frames(19) + "[line 4] in <script>\n",
+		},
+		{
+			input:    recurse,
+			maxDepth: 30,
+			want:     "[2] Error : Stack overflow.\n" + frames(10) + "... 11 more frames ...\n" + frames(9) + "[line 4] in <script>\n",
+		},
+		{
+			input:    "fun f(n) {\n  return f(\n    n + 1\n  );\n}\nf(0);\n",
+			maxDepth: 2,
+			want:     "[4] Error : Stack overflow.\n[line 4] in <fn f>\n[line 4] in <fn f>\n[line 6] in <script>\n",
+		},
+		{
+			input: recurse,
+			want:  "[2] Error : Stack overflow.\n" + frames(10) + fmt.Sprintf("... %d more frames ...\n", DefaultMaxDepth-19) + frames(9) + "[line 4] in <script>\n",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			in := NewInterpreter()
+			if test.maxDepth > 0 {
+				in.SetMaxDepth(test.maxDepth)
+			}
+
+			out, err := traceback(in, test.input)
+			assert.Equal("Stack overflow.", errorMessage(err))
+			assert.Equal(test.want, out)
+			assert.Empty(in.frames)
+		})
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	assert := assert.New(t)
+
+	source := "fun f(n) { if (n == 0) return 0; return f(n - 1) + 1; } print f(%d);"
+
+	in := NewInterpreter()
+	in.SetMaxDepth(5)
+	_, err := traceback(in, fmt.Sprintf(source, 5))
+	assert.Equal("Stack overflow.", errorMessage(err))
+
+	var out bytes.Buffer
+	in.SetStdout(&out)
+	assert.Nil(in.InterpretContext(context.Background(), parse(fmt.Sprintf(source, 4))))
+	assert.Equal("4\n", out.String())
+
+	out.Reset()
+	in.SetMaxDepth(0)
+	assert.Nil(in.InterpretContext(context.Background(), parse(fmt.Sprintf(source, DefaultMaxDepth*2))))
+	assert.Equal(fmt.Sprintln(DefaultMaxDepth*2), out.String())
+}
@@ -3,7 +3,6 @@ package interpreter
 import (
 	"github.com/iCiaran/golox/ast"
 	"github.com/iCiaran/golox/environment"
-	"github.com/iCiaran/golox/loxerror"
 )
 
 type Function struct {
@@ -11,22 +10,45 @@ type Function struct {
 	environment *environment.Environment
 }
 
-func NewFunction(declaration ast.Function, environment environment.Environment) *Function {
-	return &Function{declaration, &environment}
+func NewFunction(declaration ast.Function, environment *environment.Environment) *Function {
+	return &Function{declaration, environment}
 }
 
-func (f *Function) Call(interpreter *Interpreter, arguments []interface{}) (result interface{}) {
+func (f *Function) Call(interpreter *Interpreter, arguments []interface{}) interface{} {
 	environment := environment.NewEnvironment(f.environment)
 
 	for i := range f.declaration.Params {
 		environment.Define(f.declaration.Params[i].Lexeme, arguments[i])
 	}
 
+	if f.declaration.Generator {
+		return newGenerator(interpreter, f, environment)
+	}
+
+	if interpreter.tracer == nil {
+		return f.call(interpreter, environment)
+	}
+
+	depth := interpreter.Depth()
+	interpreter.tracer.Call(interpreter, f, arguments)
+	defer func() {
+		if r := recover(); r != nil {
+			interpreter.traceUnwind(f, depth, r)
+			panic(r)
+		}
+	}()
+
+	result := f.call(interpreter, environment)
+	interpreter.tracer.Return(interpreter, f, result)
+	return result
+}
+
+func (f *Function) call(interpreter *Interpreter, environment *environment.Environment) (result interface{}) {
 	defer func() {
 		if err := recover(); err != nil {
 			value, ok := err.(returnValue)
 			if !ok {
-				loxerror.RuntimeError(value.token, "Unknown return error")
+				panic(err)
 			}
 			result = value.value
 		}
@@ -41,5 +63,8 @@ func (f *Function) Arity() int {
 }
 
 func (f *Function) String() string {
+	if f.declaration.Name == nil {
+		return "<fn>"
+	}
 	return "<fn " + f.declaration.Name.Lexeme + ">"
 }
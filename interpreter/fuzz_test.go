@@ -0,0 +1,52 @@
+package interpreter
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/parser"
+	"github.com/iCiaran/golox/scanner"
+)
+
+func FuzzRun(f *testing.F) {
+	seeds, err := filepath.Glob("../testdata/*/*.lox")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, seed := range seeds {
+		source, err := ioutil.ReadFile(seed)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(source))
+	}
+
+	f.Fuzz(func(t *testing.T, source string) {
+		reporter := loxerror.NewReporter(ioutil.Discard)
+		statements := parser.NewParser(scanner.New(source, reporter).ScanTokens(), reporter).Parse()
+		if reporter.HadError() {
+			return
+		}
+
+		in := NewInterpreter()
+		in.SetStdin(strings.NewReader(""))
+		in.SetStdout(ioutil.Discard)
+		in.SetErrorOutput(ioutil.Discard)
+		in.SetLimits(Limits{Steps: 10000, Allocations: 1 << 20, Timeout: time.Second})
+		in.DisableNative("io", "os", "time")
+
+		err := in.InterpretContext(context.Background(), statements)
+		switch err.(type) {
+		case nil, *loxerror.Runtime, *ExitError, *StepLimitError, *AllocationLimitError, *NativeDisabledError:
+		case *CancelledError:
+			t.Fatalf("step budget did not stop execution: %v", err)
+		default:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package interpreter
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/environment"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/token"
+)
+
+type generator struct {
+	mu       sync.Mutex
+	function *Function
+	state    *generatorState
+	done     bool
+	running  bool
+}
+
+type generatorState struct {
+	interpreter *Interpreter
+	environment *environment.Environment
+	body        []ast.Stmt
+	resume      chan struct{}
+	results     chan generatorResult
+	started     bool
+}
+
+type generatorResult struct {
+	value interface{}
+	err   interface{}
+	done  bool
+}
+
+type generatorAbandoned struct{}
+
+func (generatorAbandoned) Error() string {
+	return "Generator abandoned."
+}
+
+func newGenerator(interpreter *Interpreter, function *Function, env *environment.Environment) *generator {
+	state := &generatorState{
+		environment: env,
+		body:        function.declaration.Body,
+		resume:      make(chan struct{}),
+		results:     make(chan generatorResult),
+	}
+	state.interpreter = interpreter.fork(state)
+
+	g := &generator{function: function, state: state}
+	runtime.SetFinalizer(g, (*generator).abandon)
+	return g
+}
+
+func (i *Interpreter) fork(state *generatorState) *Interpreter {
+	child := *i
+	child.environment = i.globals
+	child.frames = nil
+	child.regexes = nil
+	child.yielder = state
+	return &child
+}
+
+func (g *generator) resume(interpreter *Interpreter, call *token.Token) interface{} {
+	g.mu.Lock()
+	if g.done {
+		g.mu.Unlock()
+		return nil
+	}
+	if g.running {
+		g.mu.Unlock()
+		loxerror.RuntimeError(call, "Generator is already running.")
+	}
+	g.running = true
+	g.mu.Unlock()
+
+	child := g.state.interpreter
+	child.frames = append(append(child.frames[:0], interpreter.frames...), callFrame{g.function.String(), call, interpreter.environment})
+	child.ctx = interpreter.ctx
+	child.budget = interpreter.budget
+
+	if g.state.started {
+		g.state.resume <- struct{}{}
+	} else {
+		g.state.started = true
+		go g.state.run()
+	}
+	result := <-g.state.results
+
+	g.mu.Lock()
+	g.running = false
+	g.done = result.done
+	g.mu.Unlock()
+
+	if result.err != nil {
+		interpreter.frames = append(interpreter.frames[:0], child.frames...)
+		panic(result.err)
+	}
+	return result.value
+}
+
+func (g *generator) isDone() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.done
+}
+
+func (g *generator) abandon() {
+	if g.state.started && !g.isDone() {
+		close(g.state.resume)
+	}
+}
+
+func (s *generatorState) run() {
+	defer func() {
+		r := recover()
+		switch r.(type) {
+		case generatorAbandoned:
+			return
+		case returnValue:
+			r = nil
+		}
+		s.results <- generatorResult{err: r, done: true}
+	}()
+
+	s.interpreter.executeBlock(s.body, s.environment)
+}
+
+func (s *generatorState) yield(value interface{}) {
+	s.results <- generatorResult{value: value}
+	if _, ok := <-s.resume; !ok {
+		panic(generatorAbandoned{})
+	}
+}
+
+func (i *Interpreter) VisitYieldStmt(stmt ast.Yield) interface{} {
+	var value interface{}
+	if stmt.Value != nil {
+		value = i.evaluate(stmt.Value)
+	}
+
+	i.yielder.yield(value)
+	return nil
+}
+
+func (g *generator) get(interpreter *Interpreter, name *token.Token) interface{} {
+	switch name.Lexeme {
+	case "next":
+		return &native{"generator.next", 0, g.next}
+	case "done":
+		return g.isDone()
+	}
+
+	loxerror.RuntimeError(name, fmt.Sprintf("Undefined property '%s' on generator.", name.Lexeme))
+	return nil
+}
+
+func (g *generator) next(interpreter *Interpreter, arguments []interface{}) interface{} {
+	return g.resume(interpreter, interpreter.callSite())
+}
+
+func (g *generator) String() string {
+	if g.function.declaration.Name == nil {
+		return "<generator>"
+	}
+	return "<generator " + g.function.declaration.Name.Lexeme + ">"
+}
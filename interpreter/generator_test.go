@@ -0,0 +1,94 @@
+package interpreter
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  string
+		err   string
+	}{
+		{
+			input: "fun count(n) { for (var i = 0; i < n; i++) yield i; } var g = count(2); print g; print g.next(); print g.next(); print g.done; print g.next(); print g.done;",
+			want:  "<generator count>\n0\n1\nfalse\nnil\ntrue\n",
+		},
+		{
+			input: "fun count(n) { for (var i = 0; i < n; i++) yield i; } for (x in count(3)) print x;",
+			want:  "0\n1\n2\n",
+		},
+		{
+			input: "var g = fun () { yield 1; return; yield 2; }; for (x in g()) print x;",
+			want:  "1\n",
+		},
+		{
+			input: `for (var x in [1, 2]) print x; for (k in {"a": 1, "b": 2}) print k; for (c in "hé") print c;`,
+			want:  "1\n2\na\nb\nh\né\n",
+		},
+		{
+			input: "var fns = []; for (x in [1, 2]) push(fns, () => x); print fns[0]() + fns[1]();",
+			want:  "3\n",
+		},
+		{
+			input: "fun fib() { var a = 0; var b = 1; while (true) { yield a; var t = a + b; a = b; b = t; } } var f = fib(); for (var i = 0; i < 10; i++) f.next(); print f.next();",
+			want:  "55\n",
+		},
+		{
+			input: "var g; fun self() { yield g.next(); } g = self(); g.next();",
+			err:   "Generator is already running.",
+		},
+		{
+			input: "fun bad() { yield 1; print nope; } for (x in bad()) print x;",
+			want:  "1\n",
+			err:   "Undefined variable 'nope'.",
+		},
+		{
+			input: "for (x in 1) print x;",
+			err:   "Can only iterate over lists, maps, strings and generators.",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			out, err := run(t, test.input)
+			assert.Equal(test.err, errorMessage(err))
+			assert.Equal(test.want, out)
+		})
+	}
+}
+
+func TestGeneratorLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	in := NewInterpreter()
+	in.SetLimits(Limits{Steps: 1000})
+
+	err := in.InterpretContext(context.Background(), parse("fun loop() { while (true) yield 1; } for (x in loop()) {}"))
+	assert.Equal(&StepLimitError{1000}, err)
+}
+
+func TestAbandonedGenerators(t *testing.T) {
+	assert := assert.New(t)
+
+	before := runtime.NumGoroutine()
+
+	in := NewInterpreter()
+	err := in.InterpretContext(context.Background(), parse("fun loop() { while (true) yield 1; } for (var i = 0; i < 100; i++) loop().next();"))
+	assert.Nil(err)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(runtime.NumGoroutine(), before)
+}
@@ -0,0 +1,53 @@
+package interpreter
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/parser"
+	"github.com/iCiaran/golox/scanner"
+)
+
+func parse(source string) []ast.Stmt {
+	reporter := loxerror.NewReporter(ioutil.Discard)
+	return parser.NewParser(scanner.New(source, reporter).ScanTokens(), reporter).Parse()
+}
+
+func run(t *testing.T, source string) (string, error) {
+	t.Helper()
+	return runWithStdin(t, source, "")
+}
+
+func runWithStdin(t *testing.T, source, stdin string) (string, error) {
+	t.Helper()
+
+	var errors bytes.Buffer
+	reporter := loxerror.NewReporter(&errors)
+	statements := parser.NewParser(scanner.New(source, reporter).ScanTokens(), reporter).Parse()
+	if reporter.HadError() {
+		t.Fatalf("parse error: %s", errors.String())
+	}
+
+	var out bytes.Buffer
+	in := NewInterpreter()
+	in.SetStdin(strings.NewReader(stdin))
+	in.SetStdout(&out)
+	in.SetErrorOutput(ioutil.Discard)
+	err := in.InterpretContext(context.Background(), statements)
+	return out.String(), err
+}
+
+func errorMessage(err error) string {
+	switch e := err.(type) {
+	case nil:
+		return ""
+	case *loxerror.Runtime:
+		return e.Message
+	}
+	return err.Error()
+}
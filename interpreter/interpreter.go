@@ -1,8 +1,13 @@
 package interpreter
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"math"
+	"math/big"
+	"os"
 
 	"github.com/iCiaran/golox/ast"
 	"github.com/iCiaran/golox/environment"
@@ -10,19 +15,53 @@ import (
 	"github.com/iCiaran/golox/token"
 )
 
+const DefaultMaxDepth = 10000
+
 type Interpreter struct {
 	environment *environment.Environment
 	globals     *environment.Environment
+	frames      []callFrame
+	noScript    bool
+	maxDepth    int
+	ctx         context.Context
+	limits      Limits
+	budget      *budget
+	fileRoot    string
+	stdio       *stdio
+	reporter    *loxerror.Reporter
+	debugger    Debugger
+	tracer      Tracer
+	coverage    Coverage
+	regexes     map[string]*regex
+	yielder     *generatorState
 }
 
 func NewInterpreter() *Interpreter {
 	interpreter := new(Interpreter)
 	interpreter.environment = environment.NewEnvironment(nil)
 	interpreter.globals = interpreter.environment
-	interpreter.globals.Define("clock", &Clock{})
+	interpreter.maxDepth = DefaultMaxDepth
+	interpreter.ctx = context.Background()
+	interpreter.budget = &budget{}
+	interpreter.stdio = &stdio{in: &input{reader: bufio.NewReader(os.Stdin)}, out: os.Stdout}
+	interpreter.reporter = loxerror.NewReporter(os.Stdout)
+	interpreter.defineNatives()
+	interpreter.SetArgs(nil)
 	return interpreter
 }
 
+func (i *Interpreter) SetMaxDepth(depth int) {
+	i.maxDepth = depth
+}
+
+func (i *Interpreter) SetErrorOutput(w io.Writer) {
+	i.reporter = loxerror.NewReporter(w)
+}
+
+func (i *Interpreter) Reporter() *loxerror.Reporter {
+	return i.reporter
+}
+
 func (i *Interpreter) VisitLiteralExpr(expr ast.Literal) interface{} {
 	return expr.Value
 }
@@ -37,7 +76,24 @@ func (i *Interpreter) VisitUnaryExpr(expr ast.Unary) interface{} {
 	switch expr.Operator.Type {
 	case token.MINUS:
 		i.checkNumberOperand(expr.Operator, right)
+		switch n := right.(type) {
+		case int64:
+			i.checkOverflow(expr.Operator, n == math.MinInt64)
+			return -n
+		case *big.Int:
+			return new(big.Int).Neg(n)
+		case *big.Rat:
+			return new(big.Rat).Neg(n)
+		}
 		return -right.(float64)
+	case token.TILDE:
+		switch n := right.(type) {
+		case int64:
+			return ^n
+		case *big.Int:
+			return new(big.Int).Not(n)
+		}
+		loxerror.RuntimeError(expr.Operator, "Operand must be an integer.")
 	case token.BANG:
 		return !i.isTruthy(right)
 	}
@@ -48,43 +104,42 @@ func (i *Interpreter) VisitBinaryExpr(expr ast.Binary) interface{} {
 	left := i.evaluate(expr.Left)
 	right := i.evaluate(expr.Right)
 
-	switch expr.Operator.Type {
-	case token.MINUS:
-		i.checkNumberOperands(expr.Operator, left, right)
-		return left.(float64) - right.(float64)
-	case token.SLASH:
-		i.checkNumberOperands(expr.Operator, left, right)
-		return left.(float64) / right.(float64)
-	case token.STAR:
-		i.checkNumberOperands(expr.Operator, left, right)
-		return left.(float64) * right.(float64)
+	return i.binary(expr.Operator, left, right)
+}
+
+func (i *Interpreter) binary(operator *token.Token, left, right interface{}) interface{} {
+	switch operator.Type {
+	case token.COMMA:
+		return right
+	case token.MINUS, token.SLASH, token.STAR, token.PERCENT, token.STAR_STAR, token.TILDE_SLASH:
+		return i.arithmetic(operator, left, right)
+	case token.AMPERSAND, token.PIPE, token.CARET, token.LESS_LESS, token.GREATER_GREATER:
+		return i.bitwise(operator, left, right)
 	case token.PLUS:
-		lf, lok := left.(float64)
-		rf, rok := right.(float64)
-		if lok && rok {
-			return lf + rf
+		if isNumber(left) && isNumber(right) {
+			return i.arithmetic(operator, left, right)
+		}
+
+		_, lok := left.(string)
+		_, rok := right.(string)
+
+		if lok || rok {
+			result := stringify(left) + stringify(right)
+			i.allocate(len(result))
+			return result
 		}
 
+		loxerror.RuntimeError(operator, "Operands must be two numbers or at least one string.")
+	case token.GREATER, token.GREATER_EQUAL, token.LESS, token.LESS_EQUAL:
 		ls, lok := left.(string)
 		rs, rok := right.(string)
-
 		if lok && rok {
-			return ls + rs
+			return compareStrings(operator, ls, rs)
 		}
-
-		loxerror.RuntimeError(expr.Operator, "Operands must be two numbers or two strings.")
-	case token.GREATER:
-		i.checkNumberOperands(expr.Operator, left, right)
-		return left.(float64) > right.(float64)
-	case token.GREATER_EQUAL:
-		i.checkNumberOperands(expr.Operator, left, right)
-		return left.(float64) >= right.(float64)
-	case token.LESS:
-		i.checkNumberOperands(expr.Operator, left, right)
-		return left.(float64) < right.(float64)
-	case token.LESS_EQUAL:
-		i.checkNumberOperands(expr.Operator, left, right)
-		return left.(float64) <= right.(float64)
+		if lok || rok {
+			loxerror.RuntimeError(operator, "Operands must be two numbers or two strings.")
+		}
+		return i.compare(operator, left, right)
 	case token.BANG_EQUAL:
 		return !i.isEqual(left, right)
 	case token.EQUAL_EQUAL:
@@ -102,17 +157,62 @@ func (i *Interpreter) VisitCallExpr(expr ast.Call) interface{} {
 		arguments = append(arguments, i.evaluate(argument))
 	}
 
+	i.checkCancelled()
+
 	if function, ok := callee.(Callable); ok {
-		if len(arguments) != function.Arity() {
-			loxerror.RuntimeError(expr.Paren, fmt.Sprintf("Expected %v arguments but got %v.", function.Arity(), len(arguments)))
-		}
-		return function.Call(i, arguments)
+		return i.call(function, expr.Paren, arguments)
 	}
 
 	loxerror.RuntimeError(expr.Paren, "Can only call functions and classes.")
 	return nil
 }
 
+func (i *Interpreter) call(function Callable, paren *token.Token, arguments []interface{}) interface{} {
+	if arity := function.Arity(); arity >= 0 && len(arguments) != arity {
+		loxerror.RuntimeError(paren, fmt.Sprintf("Expected %v arguments but got %v.", function.Arity(), len(arguments)))
+	}
+	if i.maxDepth > 0 && len(i.frames) >= i.maxDepth {
+		loxerror.RuntimeError(paren, "Stack overflow.")
+	}
+	i.pushFrame(function, paren)
+	result := function.Call(i, arguments)
+	i.popFrame()
+	return result
+}
+
+func (i *Interpreter) VisitConditionalExpr(expr ast.Conditional) interface{} {
+	if i.isTruthy(i.evaluate(expr.Condition)) {
+		return i.evaluate(expr.ThenBranch)
+	}
+	return i.evaluate(expr.ElseBranch)
+}
+
+func (i *Interpreter) VisitUpdateExpr(expr ast.Update) interface{} {
+	var old, value interface{}
+
+	switch target := expr.Target.(type) {
+	case *ast.Variable:
+		old = i.environment.Get(target.Name)
+		value = i.binary(expr.Operator, old, i.evaluate(expr.Value))
+		i.environment.Assign(target.Name, value)
+	case *ast.Index:
+		object := i.evaluate(target.Object)
+		index := i.evaluate(target.Index)
+		old = i.getIndex(target.Bracket, object, index)
+		value = i.binary(expr.Operator, old, i.evaluate(expr.Value))
+		i.setIndex(target.Bracket, object, index, value)
+	}
+
+	if expr.Postfix {
+		return old
+	}
+	return value
+}
+
+func (i *Interpreter) VisitLambdaExpr(expr ast.Lambda) interface{} {
+	return NewFunction(*ast.NewFunction(nil, expr.Params, expr.Body, expr.Generator), i.environment)
+}
+
 func (i *Interpreter) VisitVariableExpr(expr ast.Variable) interface{} {
 	return i.environment.Get(expr.Name)
 }
@@ -151,7 +251,12 @@ func (i *Interpreter) VisitExpressionStmt(stmt ast.Expression) interface{} {
 }
 
 func (i *Interpreter) VisitIfStmt(stmt ast.If) interface{} {
-	if i.isTruthy(i.evaluate(stmt.Condition)) {
+	condition := i.isTruthy(i.evaluate(stmt.Condition))
+	if i.coverage != nil {
+		i.coverage.Branch(i, stmt.Keyword, condition)
+	}
+
+	if condition {
 		i.execute(stmt.ThenBranch)
 	} else if stmt.ElseBranch != nil {
 		i.execute(stmt.ElseBranch)
@@ -160,23 +265,14 @@ func (i *Interpreter) VisitIfStmt(stmt ast.If) interface{} {
 }
 
 func (i *Interpreter) VisitFunctionStmt(stmt ast.Function) interface{} {
-	function := NewFunction(stmt, *i.environment)
+	function := NewFunction(stmt, i.environment)
 	i.environment.Define(stmt.Name.Lexeme, function)
 	return nil
 }
 
 func (i *Interpreter) VisitPrintStmt(stmt ast.Print) interface{} {
 	value := i.evaluate(stmt.Expr)
-	switch value.(type) {
-	case float64:
-		if value == math.Trunc(value.(float64)) {
-			fmt.Printf("%v\n", int(value.(float64)))
-		} else {
-			fmt.Printf("%v\n", value)
-		}
-	default:
-		fmt.Printf("%v\n", value)
-	}
+	i.print(value)
 	return nil
 }
 
@@ -203,27 +299,112 @@ func (i *Interpreter) VisitVarStmt(stmt ast.Var) interface{} {
 func (i *Interpreter) VisitWhileStmt(stmt ast.While) interface{} {
 	for i.isTruthy(i.evaluate(stmt.Condition)) {
 		i.execute(stmt.Body)
+		i.checkCancelled()
 	}
 	return nil
 }
 
-func (i *Interpreter) Interpret(statements []ast.Stmt) {
+func (i *Interpreter) VisitForInStmt(stmt ast.ForIn) interface{} {
+	i.iterate(stmt.Name, i.evaluate(stmt.Iterable), func(value interface{}) {
+		env := environment.NewEnvironment(i.environment)
+		env.Define(stmt.Name.Lexeme, value)
+		i.executeBlock([]ast.Stmt{stmt.Body}, env)
+		i.checkCancelled()
+	})
+	return nil
+}
+
+func (i *Interpreter) iterate(t *token.Token, iterable interface{}, body func(value interface{})) {
+	switch it := iterable.(type) {
+	case *List:
+		for n := int64(0); ; n++ {
+			value, ok := it.get(n)
+			if !ok {
+				break
+			}
+			body(value)
+		}
+	case *Map:
+		for _, key := range it.Keys() {
+			body(key)
+		}
+	case string:
+		for _, r := range it {
+			body(string(r))
+		}
+	case *generator:
+		for value := it.resume(i, t); !it.isDone(); value = it.resume(i, t) {
+			body(value)
+		}
+	default:
+		loxerror.RuntimeError(t, "Can only iterate over lists, maps, strings and generators.")
+	}
+}
+
+func (i *Interpreter) Interpret(statements []ast.Stmt) error {
+	err := i.InterpretContext(context.Background(), statements)
+	switch err.(type) {
+	case nil, *loxerror.Runtime, *ExitError:
+	default:
+		fmt.Fprintln(i.reporter.Writer(), err)
+	}
+	return err
+}
+
+func (i *Interpreter) InterpretContext(ctx context.Context, statements []ast.Stmt) (err error) {
+	// Tasks spawned during this call share its context. It is only cancelled
+	// on failure (or when the time limit expires) so that a later call, such
+	// as the next line in the REPL, can still wait for them.
+	var cancel context.CancelFunc
+	if i.limits.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, i.limits.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	i.ctx = ctx
+	i.budget = &budget{}
+
 	defer func() {
-		if r := recover(); r != nil && !loxerror.HadRuntimeError {
-			fmt.Println("Unknown exception: ", r)
+		if r := recover(); r != nil {
+			switch e := r.(type) {
+			case *loxerror.Runtime:
+				i.reporter.RuntimeError(e)
+				i.printTraceback(e.Token)
+				err = e
+			case *ExitError, *CancelledError, *StepLimitError, *AllocationLimitError, *NativeDisabledError:
+				err = e.(error)
+			default:
+				err = fmt.Errorf("Unknown exception: %v", r)
+			}
+			cancel()
 		}
+		i.frames = i.frames[:0]
+		i.ctx = context.Background()
 	}()
 
 	for _, stmt := range statements {
 		i.execute(stmt)
 	}
+	return nil
 }
 
 func (i *Interpreter) evaluate(expr ast.Expr) interface{} {
+	i.step()
 	return expr.Accept(i)
 }
 
 func (i *Interpreter) execute(stmt ast.Stmt) {
+	i.step()
+	if i.coverage != nil {
+		i.coverage.Statement(i, stmt)
+	}
+	if i.tracer != nil {
+		i.traceStatement(stmt)
+	}
+	if i.debugger != nil {
+		i.debugStatement(stmt)
+	}
 	stmt.Accept(i)
 }
 
@@ -255,23 +436,31 @@ func (i *Interpreter) isTruthy(object interface{}) bool {
 }
 
 func (i *Interpreter) isEqual(a, b interface{}) bool {
-	return a == b
+	return valuesEqual(a, b, nil)
+}
+
+func compareStrings(operator *token.Token, l, r string) bool {
+	switch operator.Type {
+	case token.GREATER:
+		return l > r
+	case token.GREATER_EQUAL:
+		return l >= r
+	case token.LESS:
+		return l < r
+	case token.LESS_EQUAL:
+		return l <= r
+	}
+	return false
 }
 
 func (i *Interpreter) checkNumberOperand(t *token.Token, operand interface{}) {
-	switch operand.(type) {
-	case float64:
-		return
-	default:
+	if !isNumber(operand) {
 		loxerror.RuntimeError(t, "Operand must be a number.")
 	}
 }
 
 func (i *Interpreter) checkNumberOperands(t *token.Token, left, right interface{}) {
-	_, lok := left.(float64)
-	_, rok := right.(float64)
-
-	if lok && rok {
+	if isNumber(left) && isNumber(right) {
 		return
 	}
 
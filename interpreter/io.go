@@ -0,0 +1,215 @@
+package interpreter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/token"
+)
+
+type file struct {
+	path   string
+	handle *os.File
+	reader *bufio.Reader
+}
+
+func (i *Interpreter) SetFileRoot(root string) {
+	i.fileRoot = root
+}
+
+func (i *Interpreter) ioModule() *module {
+	return newModule("io",
+		&native{"readFile", 1, readFileNative},
+		&native{"writeFile", 2, writeFileNative},
+		&native{"appendFile", 2, appendFileNative},
+		&native{"readLines", 1, readLinesNative},
+		&native{"exists", 1, existsNative},
+		&native{"listDir", 1, listDirNative},
+		&native{"remove", 1, removeFileNative},
+		&native{"open", 2, openNative},
+	)
+}
+
+func readFileNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	path := interpreter.stringArgument(arguments[0])
+	content, err := ioutil.ReadFile(interpreter.resolvePath(path))
+	interpreter.checkIO(path, err)
+
+	interpreter.allocate(len(content))
+	return string(content)
+}
+
+func writeFileNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	path := interpreter.stringArgument(arguments[0])
+	err := ioutil.WriteFile(interpreter.resolvePath(path), []byte(stringify(arguments[1])), 0644)
+	interpreter.checkIO(path, err)
+	return nil
+}
+
+func appendFileNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	path := interpreter.stringArgument(arguments[0])
+	f, err := os.OpenFile(interpreter.resolvePath(path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	interpreter.checkIO(path, err)
+	defer f.Close()
+
+	_, err = f.WriteString(stringify(arguments[1]))
+	interpreter.checkIO(path, err)
+	return nil
+}
+
+func readLinesNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	content := readFileNative(interpreter, arguments).(string)
+	content = strings.TrimSuffix(content, "\n")
+
+	lines := make([]interface{}, 0)
+	if content != "" {
+		for _, line := range strings.Split(content, "\n") {
+			lines = append(lines, strings.TrimSuffix(line, "\r"))
+		}
+	}
+
+	interpreter.allocate(slotSize * len(lines))
+	return NewList(lines)
+}
+
+func existsNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	path := interpreter.stringArgument(arguments[0])
+	_, err := os.Stat(interpreter.resolvePath(path))
+	if os.IsNotExist(err) {
+		return false
+	}
+	interpreter.checkIO(path, err)
+	return true
+}
+
+func listDirNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	path := interpreter.stringArgument(arguments[0])
+	infos, err := ioutil.ReadDir(interpreter.resolvePath(path))
+	interpreter.checkIO(path, err)
+
+	names := make([]interface{}, len(infos))
+	for n, info := range infos {
+		names[n] = info.Name()
+	}
+
+	interpreter.allocate(slotSize * len(names))
+	return NewList(names)
+}
+
+func removeFileNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	path := interpreter.stringArgument(arguments[0])
+	interpreter.checkIO(path, os.Remove(interpreter.resolvePath(path)))
+	return nil
+}
+
+func openNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	path := interpreter.stringArgument(arguments[0])
+
+	var flag int
+	switch interpreter.stringArgument(arguments[1]) {
+	case "r":
+		flag = os.O_RDONLY
+	case "w":
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	case "a":
+		flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	default:
+		loxerror.RuntimeError(interpreter.callSite(), "File mode must be \"r\", \"w\" or \"a\".")
+	}
+
+	handle, err := os.OpenFile(interpreter.resolvePath(path), flag, 0644)
+	interpreter.checkIO(path, err)
+
+	return &file{path, handle, bufio.NewReader(handle)}
+}
+
+func (f *file) get(interpreter *Interpreter, name *token.Token) interface{} {
+	switch name.Lexeme {
+	case "readLine":
+		return &native{"file.readLine", 0, f.readLine}
+	case "write":
+		return &native{"file.write", 1, f.write}
+	case "close":
+		return &native{"file.close", 0, f.close}
+	}
+
+	loxerror.RuntimeError(name, fmt.Sprintf("Undefined property '%s' on file.", name.Lexeme))
+	return nil
+}
+
+func (f *file) readLine(interpreter *Interpreter, arguments []interface{}) interface{} {
+	line, err := f.reader.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return nil
+	}
+	if err != io.EOF {
+		interpreter.checkIO(f.path, err)
+	}
+
+	interpreter.allocate(len(line))
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+}
+
+func (f *file) write(interpreter *Interpreter, arguments []interface{}) interface{} {
+	_, err := f.handle.WriteString(stringify(arguments[0]))
+	interpreter.checkIO(f.path, err)
+	return nil
+}
+
+func (f *file) close(interpreter *Interpreter, arguments []interface{}) interface{} {
+	interpreter.checkIO(f.path, f.handle.Close())
+	return nil
+}
+
+func (f *file) String() string {
+	return "<file " + f.path + ">"
+}
+
+func (i *Interpreter) resolvePath(path string) string {
+	if i.fileRoot == "" {
+		return path
+	}
+
+	root, err := filepath.Abs(i.fileRoot)
+	i.checkIO(i.fileRoot, err)
+	if real, err := filepath.EvalSymlinks(root); err == nil {
+		root = real
+	}
+
+	resolved := filepath.Join(root, path)
+	if !i.withinRoot(root, resolved) {
+		loxerror.RuntimeError(i.callSite(), fmt.Sprintf("Cannot access '%s': outside of the allowed directory.", path))
+	}
+	return resolved
+}
+
+func (i *Interpreter) withinRoot(root, path string) bool {
+	for p := path; ; p = filepath.Dir(p) {
+		if real, err := filepath.EvalSymlinks(p); err == nil {
+			path = filepath.Join(real, strings.TrimPrefix(path, p))
+			break
+		}
+		if p == filepath.Dir(p) {
+			break
+		}
+	}
+
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func (i *Interpreter) checkIO(path string, err error) {
+	if err == nil {
+		return
+	}
+	if pathErr, ok := err.(*os.PathError); ok {
+		err = pathErr.Err
+	}
+	loxerror.RuntimeError(i.callSite(), fmt.Sprintf("Cannot access '%s': %v.", path, err))
+}
@@ -0,0 +1,113 @@
+package interpreter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ioFixture(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "golox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := filepath.Join(dir, "root")
+	for _, err := range []error{
+		os.MkdirAll(filepath.Join(root, "sub"), 0755),
+		ioutil.WriteFile(filepath.Join(root, "a.txt"), []byte("one\ntwo\n"), 0644),
+		ioutil.WriteFile(filepath.Join(root, "sub", "b.txt"), []byte("b"), 0644),
+		ioutil.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0644),
+		os.Symlink(dir, filepath.Join(root, "escape")),
+		os.Symlink(filepath.Join(dir, "secret.txt"), filepath.Join(root, "link.txt")),
+		os.Symlink(filepath.Join(root, "sub"), filepath.Join(root, "inner")),
+	} {
+		if err != nil {
+			os.RemoveAll(dir)
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestIO(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  string
+		err   string
+	}{
+		{input: `print io.readFile("a.txt");`, want: "one\ntwo\n\n"},
+		{input: `print io.readLines("a.txt"); print io.readLines("sub/b.txt");`, want: "[\"one\", \"two\"]\n[\"b\"]\n"},
+		{input: `io.writeFile("new.txt", "hi"); io.appendFile("new.txt", 1); print io.readFile("new.txt");`, want: "hi1\n"},
+		{input: `io.writeFile("a.txt", "x"); print io.readFile("a.txt");`, want: "x\n"},
+		{input: `print io.exists("a.txt"); print io.exists("sub"); print io.exists("missing.txt");`, want: "true\ntrue\nfalse\n"},
+		{input: `print io.listDir("sub"); print io.listDir(".");`, want: "[\"b.txt\"]\n[\"a.txt\", \"escape\", \"inner\", \"link.txt\", \"sub\"]\n"},
+		{input: `io.remove("a.txt"); print io.exists("a.txt");`, want: "false\n"},
+		{
+			input: "var f = io.open(\"out.txt\", \"w\"); f.write(\"a\r\n\"); f.write(2); f.close(); f = io.open(\"out.txt\", \"r\"); print f.readLine(); print f.readLine(); print f.readLine(); f.close();",
+			want:  "a\n2\nnil\n",
+		},
+		{input: `var f = io.open("a.txt", "a"); f.write("three"); f.close(); print io.readLines("a.txt");`, want: "[\"one\", \"two\", \"three\"]\n"},
+		{input: `print io.open("a.txt", "r");`, want: "<file a.txt>\n"},
+		{input: `io.open("a.txt", "x");`, err: "File mode must be \"r\", \"w\" or \"a\"."},
+		{input: `io.open("a.txt", "r").seek;`, err: "Undefined property 'seek' on file."},
+		{input: `io.readFile("missing.txt");`, err: "Cannot access 'missing.txt': no such file or directory."},
+		{input: `io.readLines("missing.txt");`, err: "Cannot access 'missing.txt': no such file or directory."},
+		{input: `io.listDir("missing");`, err: "Cannot access 'missing': no such file or directory."},
+		{input: `io.remove("missing.txt");`, err: "Cannot access 'missing.txt': no such file or directory."},
+		{input: `io.open("missing.txt", "r");`, err: "Cannot access 'missing.txt': no such file or directory."},
+		{input: `var f = io.open("a.txt", "r"); f.close(); f.readLine();`, err: "Cannot access 'a.txt': file already closed."},
+		{input: `var f = io.open("new.txt", "w"); f.close(); f.write("x");`, err: "Cannot access 'new.txt': file already closed."},
+		{input: `var f = io.open("a.txt", "r"); f.close(); f.close();`, err: "Cannot access 'a.txt': file already closed."},
+		{input: `print io.readFile("sub/../sub/b.txt");`, want: "b\n"},
+		{input: `io.readFile("../secret.txt");`, err: "Cannot access '../secret.txt': outside of the allowed directory."},
+		{input: `io.writeFile("sub/../../new.txt", "x");`, err: "Cannot access 'sub/../../new.txt': outside of the allowed directory."},
+		{input: `io.exists("..");`, err: "Cannot access '..': outside of the allowed directory."},
+		{input: `print io.readFile("/sub/b.txt");`, want: "b\n"},
+		{input: `io.readFile("{dir}/secret.txt");`, err: "Cannot access '{dir}/secret.txt': no such file or directory."},
+		{input: `print io.readFile("inner/b.txt");`, want: "b\n"},
+		{input: `io.readFile("link.txt");`, err: "Cannot access 'link.txt': outside of the allowed directory."},
+		{input: `io.readFile("escape/secret.txt");`, err: "Cannot access 'escape/secret.txt': outside of the allowed directory."},
+		{input: `io.writeFile("escape/new.txt", "x");`, err: "Cannot access 'escape/new.txt': outside of the allowed directory."},
+		{input: `io.listDir("escape");`, err: "Cannot access 'escape': outside of the allowed directory."},
+		{input: `io.remove("link.txt");`, err: "Cannot access 'link.txt': outside of the allowed directory."},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			dir := ioFixture(t)
+			defer os.RemoveAll(dir)
+
+			var out bytes.Buffer
+			in := NewInterpreter()
+			in.SetStdout(&out)
+			in.SetErrorOutput(ioutil.Discard)
+			in.SetFileRoot(filepath.Join(dir, "root"))
+			err := in.InterpretContext(context.Background(), parse(strings.ReplaceAll(test.input, "{dir}", dir)))
+
+			assert.Equal(strings.ReplaceAll(test.err, "{dir}", dir), errorMessage(err))
+			assert.Equal(test.want, out.String())
+		})
+	}
+}
+
+func TestIOWithoutRoot(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := ioFixture(t)
+	defer os.RemoveAll(dir)
+
+	out, err := run(t, fmt.Sprintf(`print io.readFile("%s");`, filepath.Join(dir, "root", "link.txt")))
+	assert.Nil(err)
+	assert.Equal("secret\n", out)
+}
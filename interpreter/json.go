@@ -0,0 +1,172 @@
+package interpreter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/iCiaran/golox/loxerror"
+)
+
+func jsonParseNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	source := interpreter.stringArgument(arguments[0])
+
+	var raw json.RawMessage
+	if err := json.Unmarshal([]byte(source), &raw); err != nil {
+		if syntax, ok := err.(*json.SyntaxError); ok {
+			loxerror.RuntimeError(interpreter.callSite(), fmt.Sprintf("Invalid JSON at byte %d: %s.", syntax.Offset, syntax.Error()))
+		}
+		loxerror.RuntimeError(interpreter.callSite(), fmt.Sprintf("Invalid JSON: %v.", err))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	return interpreter.decodeJSON(decoder)
+}
+
+func (i *Interpreter) decodeJSON(decoder *json.Decoder) interface{} {
+	t, _ := decoder.Token()
+
+	switch value := t.(type) {
+	case json.Delim:
+		if value == '[' {
+			elements := make([]interface{}, 0)
+			for decoder.More() {
+				i.allocate(slotSize)
+				elements = append(elements, i.decodeJSON(decoder))
+			}
+			decoder.Token()
+			return NewList(elements)
+		}
+
+		m := NewMap()
+		for decoder.More() {
+			key, _ := decoder.Token()
+			i.allocate(len(key.(string)))
+			i.mapSet(i.callSite(), m, key, i.decodeJSON(decoder))
+		}
+		decoder.Token()
+		return m
+	case json.Number:
+		return i.jsonNumber(value)
+	case string:
+		i.allocate(len(value))
+		return value
+	}
+	return t
+}
+
+func (i *Interpreter) jsonNumber(number json.Number) interface{} {
+	text := number.String()
+	if strings.ContainsAny(text, ".eE") {
+		f, _ := strconv.ParseFloat(text, 64)
+		return f
+	}
+
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return n
+	}
+
+	n, _ := new(big.Int).SetString(text, 10)
+	i.allocate(len(text))
+	return n
+}
+
+func jsonStringifyNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	if len(arguments) < 1 || len(arguments) > 2 {
+		loxerror.RuntimeError(interpreter.callSite(), fmt.Sprintf("Expected 1 or 2 arguments but got %v.", len(arguments)))
+	}
+
+	var out bytes.Buffer
+	interpreter.encodeJSON(&out, arguments[0], make(map[interface{}]bool))
+
+	if len(arguments) == 2 && arguments[1] != nil {
+		var indented bytes.Buffer
+		json.Indent(&indented, out.Bytes(), "", interpreter.jsonIndent(arguments[1]))
+		out = indented
+	}
+
+	interpreter.allocate(out.Len())
+	return out.String()
+}
+
+func (i *Interpreter) jsonIndent(indent interface{}) string {
+	switch value := indent.(type) {
+	case int64:
+		if value >= 0 && value <= 10 {
+			return strings.Repeat(" ", int(value))
+		}
+	case string:
+		return value
+	}
+
+	loxerror.RuntimeError(i.callSite(), "Indent must be a string or an integer between 0 and 10.")
+	return ""
+}
+
+func (i *Interpreter) encodeJSON(out *bytes.Buffer, value interface{}, visiting map[interface{}]bool) {
+	switch v := value.(type) {
+	case nil:
+		out.WriteString("null")
+	case bool:
+		out.WriteString(strconv.FormatBool(v))
+	case int64, *big.Int, *big.Rat:
+		out.WriteString(stringifyScalar(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			loxerror.RuntimeError(i.callSite(), fmt.Sprintf("Cannot serialise %s to JSON.", formatFloat(v)))
+		}
+		out.WriteString(formatFloat(v))
+	case string:
+		encodeJSONString(out, v)
+	case *List:
+		i.enterJSON(v, visiting)
+		out.WriteByte('[')
+		for n, element := range v.Elements() {
+			if n > 0 {
+				out.WriteByte(',')
+			}
+			i.encodeJSON(out, element, visiting)
+		}
+		out.WriteByte(']')
+		delete(visiting, v)
+	case *Map:
+		i.enterJSON(v, visiting)
+		out.WriteByte('{')
+		for n, item := range v.items() {
+			key, ok := item.key.(string)
+			if !ok {
+				loxerror.RuntimeError(i.callSite(), "JSON object keys must be strings.")
+			}
+			if n > 0 {
+				out.WriteByte(',')
+			}
+			encodeJSONString(out, key)
+			out.WriteByte(':')
+			i.encodeJSON(out, item.value, visiting)
+		}
+		out.WriteByte('}')
+		delete(visiting, v)
+	default:
+		loxerror.RuntimeError(i.callSite(), fmt.Sprintf("Cannot serialise %s to JSON.", stringify(v)))
+	}
+}
+
+func (i *Interpreter) enterJSON(collection interface{}, visiting map[interface{}]bool) {
+	if visiting[collection] {
+		loxerror.RuntimeError(i.callSite(), "Cannot serialise a cyclic structure to JSON.")
+	}
+	visiting[collection] = true
+}
+
+func encodeJSONString(out *bytes.Buffer, s string) {
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	out.Write(bytes.TrimSuffix(encoded.Bytes(), []byte("\n")))
+}
@@ -0,0 +1,62 @@
+package interpreter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		stdin string
+		want  string
+		err   string
+	}{
+		{
+			input: "print jsonParse(readAll());",
+			stdin: `{"b": [1, 2.5, 1e2, 123456789012345678901234, true, null, "x"], "a": {}}`,
+			want:  "{\"b\": [1, 2.5, 100, 123456789012345678901234, true, nil, \"x\"], \"a\": {}}\n",
+		},
+		{
+			input: "print jsonStringify(jsonParse(readAll()));",
+			stdin: `{"b": [1, "<\n>"], "a": null}`,
+			want:  "{\"b\":[1,\"<\\n>\"],\"a\":null}\n",
+		},
+		{
+			input: `print jsonStringify({"a": [1, 2n, 0.5m]}, 2);`,
+			want:  "{\n  \"a\": [\n    1,\n    2,\n    0.5\n  ]\n}\n",
+		},
+		{
+			input: `jsonParse("[1, x]");`,
+			err:   "Invalid JSON at byte 5: invalid character 'x' looking for beginning of value.",
+		},
+		{
+			input: `jsonParse("[1, 2");`,
+			err:   "Invalid JSON at byte 5: unexpected end of JSON input.",
+		},
+		{
+			input: "fun f() {} jsonStringify([f]);",
+			err:   "Cannot serialise <fn f> to JSON.",
+		},
+		{
+			input: "jsonStringify({1: 2});",
+			err:   "JSON object keys must be strings.",
+		},
+		{
+			input: `var m = {}; m["self"] = m; jsonStringify(m);`,
+			err:   "Cannot serialise a cyclic structure to JSON.",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			out, err := runWithStdin(t, test.input, test.stdin)
+			assert.Equal(test.err, errorMessage(err))
+			assert.Equal(test.want, out)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/token"
+)
+
+type object interface {
+	get(interpreter *Interpreter, name *token.Token) interface{}
+}
+
+type module struct {
+	name    string
+	members map[string]interface{}
+}
+
+func newModule(name string, natives ...*native) *module {
+	m := &module{name, make(map[string]interface{})}
+	for _, n := range natives {
+		m.members[n.name] = n
+		n.name = name + "." + n.name
+	}
+	return m
+}
+
+func (m *module) get(interpreter *Interpreter, name *token.Token) interface{} {
+	if member, ok := m.members[name.Lexeme]; ok {
+		return member
+	}
+
+	loxerror.RuntimeError(name, fmt.Sprintf("Undefined property '%s' in module %s.", name.Lexeme, m.name))
+	return nil
+}
+
+func (m *module) String() string {
+	return "<module " + m.name + ">"
+}
+
+func (i *Interpreter) VisitGetExpr(expr ast.Get) interface{} {
+	if o, ok := i.evaluate(expr.Object).(object); ok {
+		return o.get(i, expr.Name)
+	}
+
+	loxerror.RuntimeError(expr.Name, "Only modules and objects have properties.")
+	return nil
+}
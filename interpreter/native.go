@@ -0,0 +1,179 @@
+package interpreter
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/iCiaran/golox/loxerror"
+)
+
+type native struct {
+	name  string
+	arity int
+	fn    func(interpreter *Interpreter, arguments []interface{}) interface{}
+}
+
+func (n *native) Call(interpreter *Interpreter, arguments []interface{}) interface{} {
+	return n.fn(interpreter, arguments)
+}
+
+func (n *native) Arity() int {
+	return n.arity
+}
+
+func (n *native) String() string {
+	return "<native " + n.name + ">"
+}
+
+func (i *Interpreter) defineNatives() {
+	i.globals.Define("clock", &Clock{})
+	i.globals.Define("bigint", &native{"bigint", 1, bigIntNative})
+	i.globals.Define("decimal", &native{"decimal", 1, decimalNative})
+	i.globals.Define("str", &native{"str", 1, strNative})
+	i.globals.Define("len", &native{"len", 1, lenNative})
+	i.globals.Define("push", &native{"push", 2, pushNative})
+	i.globals.Define("pop", &native{"pop", 1, popNative})
+	i.globals.Define("keys", &native{"keys", 1, keysNative})
+	i.globals.Define("remove", &native{"remove", 2, removeNative})
+	i.globals.Define("input", &native{"input", 1, inputNative})
+	i.globals.Define("readLine", &native{"readLine", 0, readLineNative})
+	i.globals.Define("readAll", &native{"readAll", 0, readAllNative})
+	i.globals.Define("jsonParse", &native{"jsonParse", 1, jsonParseNative})
+	i.globals.Define("jsonStringify", &native{"jsonStringify", -1, jsonStringifyNative})
+	i.globals.Define("spawn", &native{"spawn", -1, spawnNative})
+	i.globals.Define("wait", &native{"wait", 1, waitNative})
+	i.globals.Define("chan", &native{"chan", -1, chanNative})
+	i.globals.Define("select", &native{"select", -1, selectNative})
+	i.globals.Define("assert", &native{"assert", -1, assertNative})
+	i.globals.Define("assertEqual", &native{"assertEqual", 2, assertEqualNative})
+	i.globals.Define("assertThrows", &native{"assertThrows", 1, assertThrowsNative})
+	i.globals.Define("io", i.ioModule())
+	i.globals.Define("os", i.osModule())
+	i.globals.Define("time", i.timeModule())
+	i.globals.Define("regex", i.regexModule())
+}
+
+func lenNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	switch value := arguments[0].(type) {
+	case string:
+		return int64(utf8.RuneCountInString(value))
+	case *List:
+		return int64(value.Len())
+	case *Map:
+		return int64(value.Len())
+	}
+
+	loxerror.RuntimeError(interpreter.callSite(), "Argument must be a string, list or map.")
+	return nil
+}
+
+func pushNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	list := interpreter.listArgument(arguments[0])
+	interpreter.allocate(slotSize)
+	list.push(arguments[1])
+	return nil
+}
+
+func popNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	list := interpreter.listArgument(arguments[0])
+	last, ok := list.pop()
+	if !ok {
+		loxerror.RuntimeError(interpreter.callSite(), "Cannot pop from an empty list.")
+	}
+	return last
+}
+
+func keysNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	m := interpreter.mapArgument(arguments[0])
+	interpreter.allocate(slotSize * m.Len())
+	return NewList(m.Keys())
+}
+
+func removeNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	m := interpreter.mapArgument(arguments[0])
+	value, _ := m.remove(interpreter.mapKey(interpreter.callSite(), arguments[1]))
+	return value
+}
+
+func (i *Interpreter) stringArgument(argument interface{}) string {
+	s, ok := argument.(string)
+	if !ok {
+		loxerror.RuntimeError(i.callSite(), "Argument must be a string.")
+	}
+	return s
+}
+
+func (i *Interpreter) intArgument(argument interface{}) int64 {
+	n, ok := argument.(int64)
+	if !ok {
+		loxerror.RuntimeError(i.callSite(), "Argument must be an integer.")
+	}
+	return n
+}
+
+func (i *Interpreter) listArgument(argument interface{}) *List {
+	list, ok := argument.(*List)
+	if !ok {
+		loxerror.RuntimeError(i.callSite(), "Argument must be a list.")
+	}
+	return list
+}
+
+func (i *Interpreter) mapArgument(argument interface{}) *Map {
+	m, ok := argument.(*Map)
+	if !ok {
+		loxerror.RuntimeError(i.callSite(), "Argument must be a map.")
+	}
+	return m
+}
+
+func strNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	return stringify(arguments[0])
+}
+
+func bigIntNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	switch value := arguments[0].(type) {
+	case int64:
+		return big.NewInt(value)
+	case *big.Int:
+		return value
+	case float64:
+		if value == math.Trunc(value) && !math.IsInf(value, 0) {
+			n, _ := big.NewFloat(value).Int(nil)
+			return n
+		}
+	case *big.Rat:
+		if value.IsInt() {
+			return new(big.Int).Set(value.Num())
+		}
+	case string:
+		if n, ok := new(big.Int).SetString(strings.TrimSpace(value), 0); ok {
+			return n
+		}
+	}
+
+	loxerror.RuntimeError(interpreter.callSite(), "Cannot convert argument to bigint.")
+	return nil
+}
+
+func decimalNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	switch value := arguments[0].(type) {
+	case int64, *big.Int:
+		return toRat(value)
+	case *big.Rat:
+		return value
+	case float64:
+		if r, ok := floatToRat(value); ok {
+			return r
+		}
+	case string:
+		if r, ok := new(big.Rat).SetString(strings.TrimSpace(value)); ok {
+			return r
+		}
+	}
+
+	loxerror.RuntimeError(interpreter.callSite(), "Cannot convert argument to decimal.")
+	return nil
+}
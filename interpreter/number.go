@@ -0,0 +1,268 @@
+package interpreter
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/token"
+)
+
+const (
+	intKind = iota
+	floatKind
+	bigIntKind
+	decimalKind
+)
+
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int64, float64, *big.Int, *big.Rat:
+		return true
+	}
+	return false
+}
+
+func isInteger(value interface{}) bool {
+	switch value.(type) {
+	case int64, *big.Int:
+		return true
+	}
+	return false
+}
+
+func numberKind(value interface{}) int {
+	switch value.(type) {
+	case float64:
+		return floatKind
+	case *big.Int:
+		return bigIntKind
+	case *big.Rat:
+		return decimalKind
+	}
+	return intKind
+}
+
+func toFloat(value interface{}) float64 {
+	if n, ok := value.(int64); ok {
+		return float64(n)
+	}
+	return value.(float64)
+}
+
+func (i *Interpreter) operandKind(operator *token.Token, left, right interface{}) int {
+	lk, rk := numberKind(left), numberKind(right)
+
+	kind := lk
+	if rk > kind {
+		kind = rk
+	}
+
+	if kind > floatKind && (lk == floatKind || rk == floatKind) {
+		loxerror.RuntimeError(operator, "Cannot mix floats with bigint or decimal operands.")
+	}
+	return kind
+}
+
+func (i *Interpreter) arithmetic(operator *token.Token, left, right interface{}) interface{} {
+	i.checkNumberOperands(operator, left, right)
+
+	switch i.operandKind(operator, left, right) {
+	case intKind:
+		return i.integerArithmetic(operator, left.(int64), right.(int64))
+	case floatKind:
+		return floatArithmetic(operator, toFloat(left), toFloat(right))
+	case bigIntKind:
+		return i.bigIntArithmetic(operator, toBigInt(left), toBigInt(right))
+	}
+	return i.decimalArithmetic(operator, toRat(left), toRat(right))
+}
+
+// Integer arithmetic stays exact: results that do not fit in 64 bits,
+// including left shifts that lose bits, are runtime errors rather than
+// wrapping. Use bigint literals (1n) for unbounded integers.
+func (i *Interpreter) integerArithmetic(operator *token.Token, l, r int64) interface{} {
+	switch operator.Type {
+	case token.PLUS:
+		return i.checkedAdd(operator, l, r)
+	case token.MINUS:
+		if r == math.MinInt64 {
+			i.checkOverflow(operator, l >= 0)
+			return l - r
+		}
+		return i.checkedAdd(operator, l, -r)
+	case token.STAR:
+		return i.checkedMul(operator, l, r)
+	case token.SLASH:
+		return float64(l) / float64(r)
+	case token.TILDE_SLASH:
+		i.checkDivisor(operator, r)
+		i.checkOverflow(operator, l == math.MinInt64 && r == -1)
+		return l / r
+	case token.PERCENT:
+		i.checkDivisor(operator, r)
+		return l % r
+	case token.STAR_STAR:
+		if r < 0 {
+			return math.Pow(float64(l), float64(r))
+		}
+		return i.integerPow(operator, l, r)
+	}
+	return nil
+}
+
+func floatArithmetic(operator *token.Token, l, r float64) interface{} {
+	switch operator.Type {
+	case token.PLUS:
+		return l + r
+	case token.MINUS:
+		return l - r
+	case token.STAR:
+		return l * r
+	case token.SLASH:
+		return l / r
+	case token.TILDE_SLASH:
+		return math.Trunc(l / r)
+	case token.PERCENT:
+		return math.Mod(l, r)
+	case token.STAR_STAR:
+		return math.Pow(l, r)
+	}
+	return nil
+}
+
+func (i *Interpreter) bitwise(operator *token.Token, left, right interface{}) interface{} {
+	if !isInteger(left) || !isInteger(right) {
+		loxerror.RuntimeError(operator, "Operands must be integers.")
+	}
+
+	l, lok := left.(int64)
+	r, rok := right.(int64)
+	if !lok || !rok {
+		return i.bigIntBitwise(operator, toBigInt(left), toBigInt(right))
+	}
+
+	switch operator.Type {
+	case token.AMPERSAND:
+		return l & r
+	case token.PIPE:
+		return l | r
+	case token.CARET:
+		return l ^ r
+	case token.LESS_LESS:
+		i.checkShift(operator, r)
+		shifted := l << uint64(r)
+		i.checkOverflow(operator, shifted>>uint64(r) != l)
+		return shifted
+	case token.GREATER_GREATER:
+		i.checkShift(operator, r)
+		return l >> uint64(r)
+	}
+	return nil
+}
+
+func (i *Interpreter) compare(operator *token.Token, left, right interface{}) bool {
+	i.checkNumberOperands(operator, left, right)
+
+	switch i.operandKind(operator, left, right) {
+	case intKind:
+		l, r := left.(int64), right.(int64)
+		switch operator.Type {
+		case token.GREATER:
+			return l > r
+		case token.GREATER_EQUAL:
+			return l >= r
+		case token.LESS:
+			return l < r
+		case token.LESS_EQUAL:
+			return l <= r
+		}
+	case floatKind:
+		l, r := toFloat(left), toFloat(right)
+		switch operator.Type {
+		case token.GREATER:
+			return l > r
+		case token.GREATER_EQUAL:
+			return l >= r
+		case token.LESS:
+			return l < r
+		case token.LESS_EQUAL:
+			return l <= r
+		}
+	default:
+		c := toRat(left).Cmp(toRat(right))
+		switch operator.Type {
+		case token.GREATER:
+			return c > 0
+		case token.GREATER_EQUAL:
+			return c >= 0
+		case token.LESS:
+			return c < 0
+		case token.LESS_EQUAL:
+			return c <= 0
+		}
+	}
+	return false
+}
+
+func numbersEqual(a, b interface{}) bool {
+	l, lok := a.(int64)
+	r, rok := b.(int64)
+	if lok && rok {
+		return l == r
+	}
+
+	if numberKind(a) <= floatKind && numberKind(b) <= floatKind {
+		return toFloat(a) == toFloat(b)
+	}
+
+	lr, lok := exactRat(a)
+	rr, rok := exactRat(b)
+	return lok && rok && lr.Cmp(rr) == 0
+}
+
+func (i *Interpreter) checkDivisor(operator *token.Token, divisor int64) {
+	if divisor == 0 {
+		loxerror.RuntimeError(operator, "Division by zero.")
+	}
+}
+
+func (i *Interpreter) checkShift(operator *token.Token, count int64) {
+	if count < 0 {
+		loxerror.RuntimeError(operator, "Shift count must not be negative.")
+	}
+}
+
+func (i *Interpreter) checkOverflow(operator *token.Token, overflow bool) {
+	if overflow {
+		loxerror.RuntimeError(operator, "Integer overflow.")
+	}
+}
+
+func (i *Interpreter) checkedAdd(operator *token.Token, l, r int64) int64 {
+	i.checkOverflow(operator, (r > 0 && l > math.MaxInt64-r) || (r < 0 && l < math.MinInt64-r))
+	return l + r
+}
+
+func (i *Interpreter) checkedMul(operator *token.Token, l, r int64) int64 {
+	if l == 0 || r == 0 {
+		return 0
+	}
+	product := l * r
+	i.checkOverflow(operator, product/r != l || (l == -1 && r == math.MinInt64) || (r == -1 && l == math.MinInt64))
+	return product
+}
+
+func (i *Interpreter) integerPow(operator *token.Token, base, exponent int64) int64 {
+	result := int64(1)
+	for exponent > 0 {
+		if exponent&1 == 1 {
+			result = i.checkedMul(operator, result, base)
+		}
+		exponent >>= 1
+		if exponent > 0 {
+			base = i.checkedMul(operator, base, base)
+		}
+	}
+	return result
+}
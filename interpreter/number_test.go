@@ -0,0 +1,149 @@
+package interpreter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberOperators(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  string
+		err   string
+	}{
+		{input: `print 7 % 3; print -7 % 3; print 7 % -3;`, want: "1\n-1\n1\n"},
+		{input: `print -7.5 % 2;`, want: "-1.5\n"},
+		{input: `print 1 % 0;`, err: "Division by zero."},
+		{input: `print 7 ~/ 2; print -7 ~/ 2;`, want: "3\n-3\n"},
+		{input: `print 7.5 ~/ 2; print -7.5 ~/ 2;`, want: "3\n-3\n"},
+		{input: `print 1 ~/ 0;`, err: "Division by zero."},
+		{input: `print 6 & 3; print 6 | 3; print 6 ^ 3; print ~5;`, want: "2\n7\n5\n-6\n"},
+		{input: `print 1 << 4; print -16 >> 2; print -1 << 63; print 1 >> 64;`, want: "16\n-4\n-9223372036854775808\n0\n"},
+		{input: `print 1.5 & 1;`, err: "Operands must be integers."},
+		{input: `print 1 >> -1;`, err: "Shift count must not be negative."},
+		{input: `print 1n << -1;`, err: "Shift count must not be negative."},
+		{input: `print 1n << 100000000000;`, err: "Shift count too large."},
+		{input: `print 1 + 2.5; print 2 * 1.5; print 2.0 ** 3;`, want: "3.5\n3\n8\n"},
+		{input: `print 7 / 2; print 4 / 2; print 2 ** -1;`, want: "3.5\n2\n0.5\n"},
+		{input: `print 1 == 1.0; print 1 < 1.5;`, want: "true\ntrue\n"},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			out, err := run(t, test.input)
+			assert.Equal(test.err, errorMessage(err))
+			assert.Equal(test.want, out)
+		})
+	}
+}
+
+func TestBigNumbers(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  string
+		err   string
+	}{
+		{input: `print 9223372036854775807n * 2;`, want: "18446744073709551614\n"},
+		{input: `print 2n ** 100;`, want: "1267650600228229401496703205376\n"},
+		{input: `print 1n + 1; print 7n ~/ 2; print -7n % 3; print 7n / 2;`, want: "2\n3\n-1\n3.5\n"},
+		{input: `print -3n; print -1.25m;`, want: "-3\n-1.25\n"},
+		{input: `print 0.1m + 0.2m; print 0.1m + 0.2m == 0.3m;`, want: "0.3\ntrue\n"},
+		{input: `print 1.5m * 2; print 1n + 0.5m;`, want: "3\n1.5\n"},
+		{input: `print 1m / 4; print 1m / 3;`, want: "0.25\n0.33333333333333333333\n"},
+		{input: `print 0.000001m; print 1000000000000000000000m;`, want: "0.000001\n1000000000000000000000\n"},
+		{input: `print 0.0000000000000000000001m; print 1m / 1000000000000000000000000n;`, want: "0.0000000000000000000001\n0.000000000000000000000001\n"},
+		{input: `print 123456789012345678901234.5m;`, want: "123456789012345678901234.5\n"},
+		{input: `print 1n < 2; print 2n > 1.5m; print 1n == 1; print 0.5m <= 0.5m;`, want: "true\ntrue\ntrue\ntrue\n"},
+		{input: `print 10n ** 30 > 9223372036854775807;`, want: "true\n"},
+		{input: `print 1m / 0;`, err: "Division by zero."},
+		{input: `print 1n ~/ 0;`, err: "Division by zero."},
+		{input: `print 2n ** 99999999999999;`, err: "Exponent too large."},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			out, err := run(t, test.input)
+			assert.Equal(test.err, errorMessage(err))
+			assert.Equal(test.want, out)
+		})
+	}
+}
+
+func TestMixedFloatOperands(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input  string
+		lexeme string
+		line   int
+	}{
+		{input: "print 1n\n  + 1.5;", lexeme: "+", line: 2},
+		{input: "var d = 0.5m;\nprint d <\n  1.0;", lexeme: "<", line: 2},
+		{input: "var f = 1.5;\nf\n  *= 2n;", lexeme: "*", line: 3},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			_, err := run(t, test.input)
+			runtime, ok := err.(*loxerror.Runtime)
+			if assert.True(ok) {
+				assert.Equal("Cannot mix floats with bigint or decimal operands.", runtime.Message)
+				assert.Equal(test.lexeme, runtime.Token.Lexeme)
+				assert.Equal(test.line, runtime.Token.Line)
+			}
+		})
+	}
+}
+
+func TestIntegerOverflow(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input  string
+		want   string
+		err    string
+		lexeme string
+	}{
+		{input: `print 9223372036854775806 + 1;`, want: "9223372036854775807\n"},
+		{input: `print 9223372036854775807 + 1;`, err: "Integer overflow.", lexeme: "+"},
+		{input: `print -9223372036854775807 - 1;`, want: "-9223372036854775808\n"},
+		{input: `print -9223372036854775807 - 2;`, err: "Integer overflow.", lexeme: "-"},
+		{input: `var min = -9223372036854775807 - 1; print 0 - min;`, err: "Integer overflow.", lexeme: "-"},
+		{input: `var min = -9223372036854775807 - 1; print -1 - min;`, want: "9223372036854775807\n"},
+		{input: `print 4611686018427387904 * 2;`, err: "Integer overflow.", lexeme: "*"},
+		{input: `print -4611686018427387904 * 2;`, want: "-9223372036854775808\n"},
+		{input: `var min = -9223372036854775807 - 1; print min * -1;`, err: "Integer overflow.", lexeme: "*"},
+		{input: `print 2 ** 62;`, want: "4611686018427387904\n"},
+		{input: `print 2 ** 63;`, err: "Integer overflow.", lexeme: "**"},
+		{input: `print (-2) ** 63;`, want: "-9223372036854775808\n"},
+		{input: `print 3 ** 40;`, err: "Integer overflow.", lexeme: "**"},
+		{input: `var min = -9223372036854775807 - 1; print min ~/ -1;`, err: "Integer overflow.", lexeme: "~/"},
+		{input: `var min = -9223372036854775807 - 1; print -min;`, err: "Integer overflow.", lexeme: "-"},
+		{input: `print 1 << 62; print 0 << 100;`, want: "4611686018427387904\n0\n"},
+		{input: `print 1 << 63;`, err: "Integer overflow.", lexeme: "<<"},
+		{input: `print 1 << 64;`, err: "Integer overflow.", lexeme: "<<"},
+		{input: `print 3 << 62;`, err: "Integer overflow.", lexeme: "<<"},
+		{input: `print -3 << 62;`, err: "Integer overflow.", lexeme: "<<"},
+		{input: `var n = 9223372036854775807; n++;`, err: "Integer overflow.", lexeme: "+"},
+		{input: `var n = 9223372036854775807; n += 1;`, err: "Integer overflow.", lexeme: "+"},
+		{input: `print 9223372036854775807n + 1;`, want: "9223372036854775808\n"},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			out, err := run(t, test.input)
+			assert.Equal(test.err, errorMessage(err))
+			assert.Equal(test.want, out)
+			if runtime, ok := err.(*loxerror.Runtime); ok {
+				assert.Equal(test.lexeme, runtime.Token.Lexeme)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package interpreter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperators(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  string
+		err   string
+	}{
+		{input: `print 1 + 2 * 3 - 4 / 2;`, want: "5\n"},
+		{input: `print (1 + 2) * 3;`, want: "9\n"},
+		{input: `print 2 ** 3 ** 2;`, want: "512\n"},
+		{input: `print (2 ** 3) ** 2;`, want: "64\n"},
+		{input: `print -2 ** 2;`, want: "-4\n"},
+		{input: `print 2 * 3 ** 2;`, want: "18\n"},
+		{input: `print 1 < 2 == true;`, want: "true\n"},
+		{input: `print 1 | 2 ^ 3 & 1 << 1;`, want: "1\n"},
+		{input: `print 1 or 2 and false;`, want: "1\n"},
+		{input: `print 7 % 3; print -7 % 3; print 7.5 % 2;`, want: "1\n-1\n1.5\n"},
+		{input: `print true ? 1 : false ? 2 : 3;`, want: "1\n"},
+		{input: `print false ? 1 : false ? 2 : 3;`, want: "3\n"},
+		{input: `print true ? false ? 1 : 2 : 3;`, want: "2\n"},
+		{input: `print (1, 2, 3);`, want: "3\n"},
+		{input: `var a = 1; var b = (a = 5, a + 1); print a; print b;`, want: "5\n6\n"},
+		{input: `var l = [1, 2]; l[0] += 10; l[1] *= 3; print l;`, want: "[11, 6]\n"},
+		{input: `var m = {"k": 1}; m["k"] -= 3; print m;`, want: "{\"k\": -2}\n"},
+		{input: `var l = [1, 2]; var i = 0; l[i++] += 1; print l; print i;`, want: "[2, 2]\n1\n"},
+		{input: `var y = 1; y /= 2; print y;`, want: "0.5\n"},
+		{input: `var x = 5; print x++; print x;`, want: "5\n6\n"},
+		{input: `var x = 5; print ++x; print x;`, want: "6\n6\n"},
+		{input: `var x = 5; print x--; print --x;`, want: "5\n3\n"},
+		{input: `var l = [1]; print l[0]++; print ++l[0];`, want: "1\n3\n"},
+		{input: `var s = "a"; s += "b"; print s;`, want: "ab\n"},
+		{input: `var s = "a"; s -= 1;`, err: "Operands must be numbers."},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			out, err := run(t, test.input)
+			assert.Equal(test.err, errorMessage(err))
+			assert.Equal(test.want, out)
+		})
+	}
+}
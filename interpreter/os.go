@@ -0,0 +1,56 @@
+package interpreter
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/iCiaran/golox/loxerror"
+)
+
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("Exit with code %d.", e.Code)
+}
+
+func (i *Interpreter) osModule() *module {
+	return newModule("os",
+		&native{"getenv", 1, getenvNative},
+		&native{"setenv", 2, setenvNative},
+		&native{"exit", 1, exitNative},
+	)
+}
+
+func getenvNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	value, ok := os.LookupEnv(interpreter.stringArgument(arguments[0]))
+	if !ok {
+		return nil
+	}
+	return value
+}
+
+func setenvNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	name := interpreter.stringArgument(arguments[0])
+
+	var err error
+	if arguments[1] == nil {
+		err = os.Unsetenv(name)
+	} else {
+		err = os.Setenv(name, interpreter.stringArgument(arguments[1]))
+	}
+
+	if err != nil {
+		loxerror.RuntimeError(interpreter.callSite(), fmt.Sprintf("Cannot set environment variable '%s': %v.", name, err))
+	}
+	return nil
+}
+
+func exitNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	code := interpreter.intArgument(arguments[0])
+	if code < 0 || code > 255 {
+		loxerror.RuntimeError(interpreter.callSite(), "Exit code must be between 0 and 255.")
+	}
+	panic(&ExitError{int(code)})
+}
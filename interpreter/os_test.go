@@ -0,0 +1,78 @@
+package interpreter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOS(t *testing.T) {
+	assert := assert.New(t)
+
+	os.Setenv("GOLOX_TEST", "value")
+	defer os.Unsetenv("GOLOX_TEST")
+
+	stamp := time.Date(2020, 5, 17, 10, 30, 0, 0, time.UTC)
+	millis := stamp.UnixNano() / int64(time.Millisecond)
+
+	tests := []struct {
+		input string
+		want  string
+		err   error
+	}{
+		{
+			input: `print os.getenv("GOLOX_TEST"); print os.getenv("GOLOX_MISSING");`,
+			want:  "value\nnil\n",
+		},
+		{
+			input: `os.setenv("GOLOX_TEST", "other"); print os.getenv("GOLOX_TEST"); os.setenv("GOLOX_TEST", nil); print os.getenv("GOLOX_TEST");`,
+			want:  "other\nnil\n",
+		},
+		{
+			input: `print "before"; fun f() { os.exit(3); } f(); print "after";`,
+			want:  "before\n",
+			err:   &ExitError{3},
+		},
+		{
+			input: `print time.parse("2006-01-02 15:04 -0700", "2020-05-17 10:30 +0000");`,
+			want:  fmt.Sprintln(millis),
+		},
+		{
+			input: fmt.Sprintf(`print time.format(%d, "2006-01-02 15:04");`, millis),
+			want:  fmt.Sprintln(stamp.Local().Format("2006-01-02 15:04")),
+		},
+		{
+			input: "var start = time.millis(); time.sleep(5); print time.millis() - start >= 5;",
+			want:  "true\n",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			var out bytes.Buffer
+			in := NewInterpreter()
+			in.SetStdout(&out)
+
+			assert.Equal(test.err, in.InterpretContext(context.Background(), parse(test.input)))
+			assert.Equal(test.want, out.String())
+		})
+	}
+}
+
+func TestSleepCancellation(t *testing.T) {
+	assert := assert.New(t)
+
+	in := NewInterpreter()
+	in.SetLimits(Limits{Timeout: 10 * time.Millisecond})
+
+	start := time.Now()
+	err := in.InterpretContext(context.Background(), parse("time.sleep(10000);"))
+
+	assert.IsType(&CancelledError{}, err)
+	assert.True(time.Since(start) < time.Second)
+}
@@ -0,0 +1,146 @@
+package interpreter
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/token"
+)
+
+const maxCachedRegexes = 256
+
+type regex struct {
+	re *regexp.Regexp
+}
+
+type regexMethod func(r *regex, interpreter *Interpreter, arguments []interface{}) interface{}
+
+func (i *Interpreter) regexModule() *module {
+	return newModule("regex",
+		regexNative("match", 1, (*regex).match),
+		regexNative("find", 1, (*regex).find),
+		regexNative("findAll", 1, (*regex).findAll),
+		regexNative("replace", 2, (*regex).replace),
+		regexNative("split", 1, (*regex).split),
+		&native{"compile", 1, compileNative},
+	)
+}
+
+func regexNative(name string, arity int, method regexMethod) *native {
+	return &native{name, arity + 1, func(interpreter *Interpreter, arguments []interface{}) interface{} {
+		return method(interpreter.regexArgument(arguments[0]), interpreter, arguments[1:])
+	}}
+}
+
+func compileNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	return interpreter.regexArgument(arguments[0])
+}
+
+func (i *Interpreter) regexArgument(argument interface{}) *regex {
+	if r, ok := argument.(*regex); ok {
+		return r
+	}
+
+	pattern := i.stringArgument(argument)
+	if r, ok := i.regexes[pattern]; ok {
+		return r
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		loxerror.RuntimeError(i.callSite(), fmt.Sprintf("Invalid regular expression: %v.", err))
+	}
+
+	if i.regexes == nil || len(i.regexes) >= maxCachedRegexes {
+		i.regexes = make(map[string]*regex)
+	}
+	r := &regex{re}
+	i.regexes[pattern] = r
+	return r
+}
+
+func (r *regex) get(interpreter *Interpreter, name *token.Token) interface{} {
+	switch name.Lexeme {
+	case "pattern":
+		return r.re.String()
+	case "match":
+		return r.bind("match", 1, (*regex).match)
+	case "find":
+		return r.bind("find", 1, (*regex).find)
+	case "findAll":
+		return r.bind("findAll", 1, (*regex).findAll)
+	case "replace":
+		return r.bind("replace", 2, (*regex).replace)
+	case "split":
+		return r.bind("split", 1, (*regex).split)
+	}
+
+	loxerror.RuntimeError(name, fmt.Sprintf("Undefined property '%s' on regex.", name.Lexeme))
+	return nil
+}
+
+func (r *regex) bind(name string, arity int, method regexMethod) *native {
+	return &native{"regex." + name, arity, func(interpreter *Interpreter, arguments []interface{}) interface{} {
+		return method(r, interpreter, arguments)
+	}}
+}
+
+func (r *regex) match(interpreter *Interpreter, arguments []interface{}) interface{} {
+	return r.re.MatchString(interpreter.stringArgument(arguments[0]))
+}
+
+func (r *regex) find(interpreter *Interpreter, arguments []interface{}) interface{} {
+	s := interpreter.stringArgument(arguments[0])
+	indices := r.re.FindStringSubmatchIndex(s)
+	if indices == nil {
+		return nil
+	}
+	return interpreter.captures(s, indices)
+}
+
+func (r *regex) findAll(interpreter *Interpreter, arguments []interface{}) interface{} {
+	s := interpreter.stringArgument(arguments[0])
+	matches := make([]interface{}, 0)
+	for _, indices := range r.re.FindAllStringSubmatchIndex(s, -1) {
+		interpreter.allocate(slotSize)
+		matches = append(matches, interpreter.captures(s, indices))
+	}
+	return NewList(matches)
+}
+
+func (r *regex) replace(interpreter *Interpreter, arguments []interface{}) interface{} {
+	s := interpreter.stringArgument(arguments[0])
+	replacement := interpreter.stringArgument(arguments[1])
+	result := r.re.ReplaceAllString(s, replacement)
+	interpreter.allocate(len(result))
+	return result
+}
+
+func (r *regex) split(interpreter *Interpreter, arguments []interface{}) interface{} {
+	s := interpreter.stringArgument(arguments[0])
+	parts := r.re.Split(s, -1)
+	elements := make([]interface{}, len(parts))
+	for n, part := range parts {
+		interpreter.allocate(slotSize + len(part))
+		elements[n] = part
+	}
+	return NewList(elements)
+}
+
+func (r *regex) String() string {
+	return "<regex " + r.re.String() + ">"
+}
+
+func (i *Interpreter) captures(s string, indices []int) *List {
+	groups := make([]interface{}, len(indices)/2)
+	for n := range groups {
+		start, end := indices[2*n], indices[2*n+1]
+		i.allocate(slotSize)
+		if start >= 0 {
+			i.allocate(end - start)
+			groups[n] = s[start:end]
+		}
+	}
+	return NewList(groups)
+}
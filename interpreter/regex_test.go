@@ -0,0 +1,59 @@
+package interpreter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegex(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  string
+		err   string
+	}{
+		{
+			input: `print regex.match("^a+b$", "aaab"); print regex.match("^a+b$", "ba");`,
+			want:  "true\nfalse\n",
+		},
+		{
+			input: `print regex.find("(\w+)@(\w+)?", "mail bob@ x@y"); print regex.find("z", "abc");`,
+			want:  "[\"bob@\", \"bob\", nil]\nnil\n",
+		},
+		{
+			input: `print regex.findAll("(\w+)@(\w+)", "a@b c@d");`,
+			want:  "[[\"a@b\", \"a\", \"b\"], [\"c@d\", \"c\", \"d\"]]\n",
+		},
+		{
+			input: `print regex.replace("(\w+)@(\w+)", "a@b c@d", "$2 at ${1}");`,
+			want:  "b at a d at c\n",
+		},
+		{
+			input: `print regex.split(",\s*", "a, b,c");`,
+			want:  "[\"a\", \"b\", \"c\"]\n",
+		},
+		{
+			input: `var r = regex.compile("[0-9]+"); print r; print r.pattern; print r.findAll("1 22"); print regex.match(r, "x1");`,
+			want:  "<regex [0-9]+>\n[0-9]+\n[[\"1\"], [\"22\"]]\ntrue\n",
+		},
+		{
+			input: `regex.match("(a", "a");`,
+			err:   "Invalid regular expression: error parsing regexp: missing closing ): `(a`.",
+		},
+		{
+			input: `regex.compile("a").flags;`,
+			err:   "Undefined property 'flags' on regex.",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			out, err := run(t, test.input)
+			assert.Equal(test.err, errorMessage(err))
+			assert.Equal(test.want, out)
+		})
+	}
+}
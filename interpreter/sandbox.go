@@ -0,0 +1,114 @@
+package interpreter
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/iCiaran/golox/token"
+)
+
+type Limits struct {
+	Steps       int
+	Allocations int
+	Timeout     time.Duration
+}
+
+type CancelledError struct {
+	Err error
+}
+
+func (e *CancelledError) Error() string {
+	return fmt.Sprintf("Execution cancelled: %v.", e.Err)
+}
+
+func (e *CancelledError) Unwrap() error {
+	return e.Err
+}
+
+type StepLimitError struct {
+	Limit int
+}
+
+func (e *StepLimitError) Error() string {
+	return fmt.Sprintf("Step limit of %d exceeded.", e.Limit)
+}
+
+type AllocationLimitError struct {
+	Limit int
+}
+
+func (e *AllocationLimitError) Error() string {
+	return fmt.Sprintf("Allocation limit of %d bytes exceeded.", e.Limit)
+}
+
+type NativeDisabledError struct {
+	Name string
+}
+
+func (e *NativeDisabledError) Error() string {
+	return fmt.Sprintf("Native function '%s' is disabled.", e.Name)
+}
+
+type budget struct {
+	steps     int64
+	allocated int64
+}
+
+type disabledNative struct {
+	name string
+}
+
+func (d *disabledNative) Call(interpreter *Interpreter, arguments []interface{}) interface{} {
+	panic(&NativeDisabledError{d.name})
+}
+
+func (d *disabledNative) Arity() int {
+	return -1
+}
+
+func (d *disabledNative) get(interpreter *Interpreter, name *token.Token) interface{} {
+	return &disabledNative{d.name + "." + name.Lexeme}
+}
+
+func (d *disabledNative) String() string {
+	return "<native " + d.name + ">"
+}
+
+func (i *Interpreter) SetLimits(limits Limits) {
+	i.limits = limits
+}
+
+func (i *Interpreter) DisableNative(names ...string) {
+	for _, name := range names {
+		i.globals.Define(name, &disabledNative{name})
+	}
+}
+
+func (i *Interpreter) step() {
+	if i.limits.Steps <= 0 {
+		return
+	}
+
+	if atomic.AddInt64(&i.budget.steps, 1) > int64(i.limits.Steps) {
+		panic(&StepLimitError{i.limits.Steps})
+	}
+}
+
+func (i *Interpreter) allocate(size int) {
+	if i.limits.Allocations <= 0 {
+		return
+	}
+
+	if atomic.AddInt64(&i.budget.allocated, int64(size)) > int64(i.limits.Allocations) {
+		panic(&AllocationLimitError{i.limits.Allocations})
+	}
+}
+
+func (i *Interpreter) checkCancelled() {
+	select {
+	case <-i.ctx.Done():
+		panic(&CancelledError{i.ctx.Err()})
+	default:
+	}
+}
@@ -0,0 +1,73 @@
+package interpreter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input   string
+		limits  Limits
+		disable []string
+		want    error
+	}{
+		{
+			input:  "var a = 0; while (true) { a = a + 1; }",
+			limits: Limits{Steps: 1000},
+			want:   &StepLimitError{1000},
+		},
+		{
+			input:  `var s = "ab"; while (true) { s = s + s; }`,
+			limits: Limits{Allocations: 1024},
+			want:   &AllocationLimitError{1024},
+		},
+		{
+			input:  "var l = []; while (true) { push(l, l); }",
+			limits: Limits{Allocations: 1024},
+			want:   &AllocationLimitError{1024},
+		},
+		{
+			input:   "clock();",
+			disable: []string{"clock"},
+			want:    &NativeDisabledError{"clock"},
+		},
+		{
+			input:  "var a = 0; while (a < 10) { a = a + 1; }",
+			limits: Limits{Steps: 1000},
+			want:   nil,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			in := NewInterpreter()
+			in.SetLimits(test.limits)
+			in.DisableNative(test.disable...)
+			err := in.InterpretContext(context.Background(), parse(test.input))
+			assert.Equal(test.want, err)
+		})
+	}
+}
+
+func TestSandboxCancellation(t *testing.T) {
+	assert := assert.New(t)
+
+	in := NewInterpreter()
+	in.SetLimits(Limits{Timeout: 10 * time.Millisecond})
+	err := in.InterpretContext(context.Background(), parse("while (true) {}"))
+	assert.IsType(&CancelledError{}, err)
+	assert.True(errors.Is(err, context.DeadlineExceeded))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = NewInterpreter().InterpretContext(ctx, parse("fun f() { return f(); } f();"))
+	assert.True(errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,98 @@
+package interpreter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+)
+
+type stdio struct {
+	mu  sync.Mutex
+	in  *input
+	out io.Writer
+}
+
+type input struct {
+	mu     sync.Mutex
+	reader *bufio.Reader
+}
+
+func (s *stdio) write(text string) {
+	s.mu.Lock()
+	io.WriteString(s.out, text)
+	s.mu.Unlock()
+}
+
+func (s *stdio) input() *input {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.in
+}
+
+func (in *input) readLine() (string, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.reader.ReadString('\n')
+}
+
+func (in *input) readAll() ([]byte, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return ioutil.ReadAll(in.reader)
+}
+
+func (i *Interpreter) SetStdin(r io.Reader) {
+	i.stdio.mu.Lock()
+	i.stdio.in = &input{reader: bufio.NewReader(r)}
+	i.stdio.mu.Unlock()
+}
+
+func (i *Interpreter) SetStdout(w io.Writer) {
+	i.stdio.mu.Lock()
+	i.stdio.out = w
+	i.stdio.mu.Unlock()
+}
+
+func (i *Interpreter) SetArgs(args []string) {
+	list := make([]interface{}, len(args))
+	for n, arg := range args {
+		list[n] = arg
+	}
+	i.globals.Define("args", NewList(list))
+}
+
+func inputNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	interpreter.stdio.write(stringify(arguments[0]))
+	return readLineNative(interpreter, arguments)
+}
+
+func readLineNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	line, err := interpreter.stdio.input().readLine()
+	if err == io.EOF && line == "" {
+		return nil
+	}
+	if err != nil && err != io.EOF {
+		interpreter.checkIO("stdin", err)
+	}
+
+	interpreter.allocate(len(line))
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+}
+
+func readAllNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	content, err := interpreter.stdio.input().readAll()
+	interpreter.checkIO("stdin", err)
+	if len(content) == 0 {
+		return nil
+	}
+
+	interpreter.allocate(len(content))
+	return string(content)
+}
+
+func (i *Interpreter) print(value interface{}) {
+	i.stdio.write(fmt.Sprintln(stringify(value)))
+}
@@ -0,0 +1,68 @@
+package interpreter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStdio(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		stdin string
+		args  []string
+		want  string
+	}{
+		{
+			input: "print args;",
+			args:  []string{"a", "-b"},
+			want:  "[\"a\", \"-b\"]\n",
+		},
+		{
+			input: `var name = input("name? "); print name;`,
+			stdin: "lox\n",
+			want:  "name? lox\n",
+		},
+		{
+			input: "print readLine(); print readLine(); print readLine();",
+			stdin: "one\r\ntwo",
+			want:  "one\ntwo\nnil\n",
+		},
+		{
+			input: "print readLine(); print readAll(); print readAll();",
+			stdin: "one\ntwo\nthree\n",
+			want:  "one\ntwo\nthree\n\nnil\n",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			var out bytes.Buffer
+			in := NewInterpreter()
+			in.SetStdin(strings.NewReader(test.stdin))
+			in.SetStdout(&out)
+			in.SetArgs(test.args)
+
+			assert.Nil(in.InterpretContext(context.Background(), parse(test.input)))
+			assert.Equal(test.want, out.String())
+		})
+	}
+}
+
+func TestErrorsDefaultToStdout(t *testing.T) {
+	assert := assert.New(t)
+
+	in := NewInterpreter()
+	assert.Equal(os.Stdout, in.Reporter().Writer())
+
+	clone, err := in.Clone()
+	assert.Nil(err)
+	assert.Equal(os.Stdout, clone.Reporter().Writer())
+}
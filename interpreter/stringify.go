@@ -0,0 +1,100 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+func Stringify(value interface{}) string {
+	return stringify(value)
+}
+
+func stringify(value interface{}) string {
+	var sb strings.Builder
+	writeValue(&sb, value, false, make(map[interface{}]bool))
+	return sb.String()
+}
+
+func writeValue(sb *strings.Builder, value interface{}, quote bool, visiting map[interface{}]bool) {
+	switch v := value.(type) {
+	case string:
+		if quote {
+			sb.WriteString(strconv.Quote(v))
+			return
+		}
+	case *List:
+		if visiting[v] {
+			sb.WriteString("[...]")
+			return
+		}
+		visiting[v] = true
+		sb.WriteRune('[')
+		for n, element := range v.Elements() {
+			if n > 0 {
+				sb.WriteString(", ")
+			}
+			writeValue(sb, element, true, visiting)
+		}
+		sb.WriteRune(']')
+		delete(visiting, v)
+		return
+	case *Map:
+		if visiting[v] {
+			sb.WriteString("{...}")
+			return
+		}
+		visiting[v] = true
+		sb.WriteRune('{')
+		for n, item := range v.items() {
+			if n > 0 {
+				sb.WriteString(", ")
+			}
+			writeValue(sb, item.key, true, visiting)
+			sb.WriteString(": ")
+			writeValue(sb, item.value, true, visiting)
+		}
+		sb.WriteRune('}')
+		delete(visiting, v)
+		return
+	}
+	sb.WriteString(stringifyScalar(value))
+}
+
+func stringifyScalar(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return formatFloat(v)
+	case *big.Int:
+		return v.String()
+	case *big.Rat:
+		return formatDecimal(v)
+	case string:
+		return v
+	case Callable:
+		return v.String()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,40 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringify(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input interface{}
+		want  string
+	}{
+		{input: nil, want: "nil"},
+		{input: true, want: "true"},
+		{input: false, want: "false"},
+		{input: int64(-42), want: "-42"},
+		{input: 2.0, want: "2"},
+		{input: 0.5, want: "0.5"},
+		{input: 1e21, want: "1000000000000000000000"},
+		{input: math.NaN(), want: "nan"},
+		{input: math.Inf(-1), want: "-inf"},
+		{input: big.NewInt(7), want: "7"},
+		{input: big.NewRat(1, 8), want: "0.125"},
+		{input: big.NewRat(2, 3), want: "0.66666666666666666667"},
+		{input: "text", want: "text"},
+		{input: &Clock{}, want: "<native clock>"},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			assert.Equal(test.want, stringify(test.input))
+		})
+	}
+}
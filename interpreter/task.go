@@ -0,0 +1,104 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/token"
+)
+
+type task struct {
+	callee   Callable
+	finished chan struct{}
+	frames   []callFrame
+	result   interface{}
+	err      interface{}
+}
+
+func spawnNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	if len(arguments) == 0 {
+		loxerror.RuntimeError(interpreter.callSite(), "Expected a function to spawn.")
+	}
+
+	callee, ok := arguments[0].(Callable)
+	if !ok {
+		loxerror.RuntimeError(interpreter.callSite(), "Can only spawn functions.")
+	}
+
+	call := interpreter.callSite()
+	child := interpreter.fork(nil)
+	child.frames = append(child.frames, interpreter.frames...)
+
+	t := &task{callee: callee, finished: make(chan struct{})}
+	go t.run(child, call, arguments[1:])
+	return t
+}
+
+func (t *task) run(interpreter *Interpreter, call *token.Token, arguments []interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.err = r
+			t.frames = interpreter.frames
+		}
+		close(t.finished)
+	}()
+
+	t.result = interpreter.call(t.callee, call, arguments)
+}
+
+func (t *task) wait(interpreter *Interpreter) interface{} {
+	select {
+	case <-t.finished:
+	case <-interpreter.ctx.Done():
+		panic(&CancelledError{interpreter.ctx.Err()})
+	}
+
+	if t.err != nil {
+		interpreter.frames = append(interpreter.frames[:0], t.frames...)
+		panic(t.err)
+	}
+	return t.result
+}
+
+func (t *task) done() bool {
+	select {
+	case <-t.finished:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t *task) get(interpreter *Interpreter, name *token.Token) interface{} {
+	switch name.Lexeme {
+	case "wait":
+		return &native{"task.wait", 0, func(interpreter *Interpreter, arguments []interface{}) interface{} {
+			return t.wait(interpreter)
+		}}
+	case "done":
+		return t.done()
+	}
+
+	loxerror.RuntimeError(name, fmt.Sprintf("Undefined property '%s' on task.", name.Lexeme))
+	return nil
+}
+
+func (t *task) String() string {
+	return "<task " + t.callee.String() + ">"
+}
+
+func waitNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	tasks := interpreter.listArgument(arguments[0]).Elements()
+	for _, value := range tasks {
+		if _, ok := value.(*task); !ok {
+			loxerror.RuntimeError(interpreter.callSite(), "Can only wait for tasks.")
+		}
+	}
+
+	interpreter.allocate(slotSize * len(tasks))
+	results := make([]interface{}, len(tasks))
+	for n, value := range tasks {
+		results[n] = value.(*task).wait(interpreter)
+	}
+	return NewList(results)
+}
@@ -0,0 +1,98 @@
+package interpreter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasks(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  string
+		err   string
+	}{
+		{
+			input: "fun square(n) { return n * n; } var t = spawn(square, 7); print t.wait(); print t.done;",
+			want:  "49\ntrue\n",
+		},
+		{
+			input: "var tasks = []; for (var i = 0; i < 10; i++) push(tasks, spawn((n) => n + 1, i)); print wait(tasks);",
+			want:  "[1, 2, 3, 4, 5, 6, 7, 8, 9, 10]\n",
+		},
+		{
+			input: `var results = chan(); var shared = {};
+				fun work(n) { for (var i = 0; i < 100; i++) { shared[n] = i; push(args, i); } results.send(n); }
+				for (var i = 0; i < 8; i++) spawn(work, i);
+				var total = 0;
+				for (var i = 0; i < 8; i++) total = total + results.recv();
+				print total; print len(shared); print len(args);`,
+			want: "28\n8\n800\n",
+		},
+		{
+			input: `var c = chan(2); print c.trySend(1); print c.trySend(2); print c.trySend(3); print c; c.close(); print c.recv(); print c.recv(); print c.recv();`,
+			want:  "true\ntrue\nfalse\n<channel 2>\n1\n2\nnil\n",
+		},
+		{
+			input: `var a = chan(); var b = chan(1); b.send("b"); var r = select([a, b]); print r[0] == b; print r[1]; print select([a], 10); print a.tryRecv();`,
+			want:  "true\nb\nnil\nnil\n",
+		},
+		{
+			input: "var c = chan(); c.close(); c.send(1);",
+			err:   "Cannot send on a closed channel.",
+		},
+		{
+			input: "var c = chan(); c.close(); c.close();",
+			err:   "Channel is already closed.",
+		},
+		{
+			input: "fun fail() { return nope; } spawn(fail).wait();",
+			err:   "Undefined variable 'nope'.",
+		},
+		{
+			input: "spawn(1);",
+			err:   "Can only spawn functions.",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			out, err := run(t, test.input)
+			assert.Equal(test.err, errorMessage(err))
+			assert.Equal(test.want, out)
+		})
+	}
+}
+
+func TestTaskCancellation(t *testing.T) {
+	assert := assert.New(t)
+
+	in := NewInterpreter()
+	in.SetLimits(Limits{Timeout: 10 * time.Millisecond})
+
+	err := in.InterpretContext(context.Background(), parse("var c = chan(); spawn(fun () { while (true) {} }); c.recv();"))
+	assert.IsType(&CancelledError{}, err)
+}
+
+func TestTasksOutliveInterpret(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	in := NewInterpreter()
+	in.SetStdout(&out)
+	in.SetErrorOutput(ioutil.Discard)
+
+	assert.Nil(in.Interpret(parse("fun slow() { time.sleep(20); return 1; } var t = spawn(slow);")))
+	assert.Nil(in.Interpret(parse("print t.wait();")))
+	assert.Equal("1\n", out.String())
+
+	assert.NotNil(in.Interpret(parse("var c = chan(); var u = spawn(() => c.recv()); nope;")))
+	assert.IsType(&CancelledError{}, in.Interpret(parse("u.wait();")))
+}
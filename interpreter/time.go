@@ -0,0 +1,64 @@
+package interpreter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iCiaran/golox/loxerror"
+)
+
+func (i *Interpreter) timeModule() *module {
+	return newModule("time",
+		&native{"millis", 0, millisNative},
+		&native{"nanos", 0, nanosNative},
+		&native{"sleep", 1, sleepNative},
+		&native{"format", 2, formatTimeNative},
+		&native{"parse", 2, parseTimeNative},
+	)
+}
+
+func millisNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
+
+func nanosNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	return time.Now().UnixNano()
+}
+
+func sleepNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	ms := interpreter.intArgument(arguments[0])
+	if ms < 0 {
+		loxerror.RuntimeError(interpreter.callSite(), "Sleep duration must not be negative.")
+	}
+
+	timer := time.NewTimer(time.Duration(ms) * time.Millisecond)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-interpreter.ctx.Done():
+		panic(&CancelledError{interpreter.ctx.Err()})
+	}
+	return nil
+}
+
+func formatTimeNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	ms := interpreter.intArgument(arguments[0])
+	layout := interpreter.stringArgument(arguments[1])
+	return fromMillis(ms).Format(layout)
+}
+
+func parseTimeNative(interpreter *Interpreter, arguments []interface{}) interface{} {
+	layout := interpreter.stringArgument(arguments[0])
+	value := interpreter.stringArgument(arguments[1])
+
+	t, err := time.ParseInLocation(layout, value, time.Local)
+	if err != nil {
+		loxerror.RuntimeError(interpreter.callSite(), fmt.Sprintf("Cannot parse time '%s' with layout '%s'.", value, layout))
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func fromMillis(ms int64) time.Time {
+	return time.Unix(ms/1000, ms%1000*int64(time.Millisecond))
+}
@@ -0,0 +1,38 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/iCiaran/golox/ast"
+)
+
+type Tracer interface {
+	Statement(interpreter *Interpreter, stmt ast.Stmt, line int)
+	Call(interpreter *Interpreter, function Callable, arguments []interface{})
+	Return(interpreter *Interpreter, function Callable, value interface{})
+	Unwind(interpreter *Interpreter, function Callable, err error)
+}
+
+func (i *Interpreter) SetTracer(tracer Tracer) {
+	i.tracer = tracer
+}
+
+func (i *Interpreter) traceStatement(stmt ast.Stmt) {
+	if t := StatementToken(stmt); t != nil {
+		i.tracer.Statement(i, stmt, t.Line)
+	}
+}
+
+func (i *Interpreter) traceUnwind(function Callable, depth int, reason interface{}) {
+	err, ok := reason.(error)
+	if !ok {
+		err = fmt.Errorf("%v", reason)
+	}
+
+	frames := i.frames
+	if depth < len(frames) {
+		i.frames = frames[:depth]
+	}
+	i.tracer.Unwind(i, function, err)
+	i.frames = frames
+}
@@ -2,31 +2,90 @@ package loxerror
 
 import (
 	"fmt"
+	"io"
+	"sync"
 
 	"github.com/iCiaran/golox/token"
 )
 
-var (
-	HadError        bool = false
-	HadRuntimeError bool = false
-)
+type Reporter struct {
+	mu              sync.Mutex
+	out             io.Writer
+	hadError        bool
+	hadRuntimeError bool
+}
 
-func Error(line int, where, message string) {
-	fmt.Printf("[%d] Error %s: %s\n", line, where, message)
-	HadError = true
+func NewReporter(out io.Writer) *Reporter {
+	return &Reporter{out: out}
 }
 
-func ParseError(t *token.Token, message string) {
-	if t.Type == token.EOF {
-		Error(t.Line, "at end", message)
+func (r *Reporter) Writer() io.Writer {
+	return r.out
+}
+
+func (r *Reporter) HadError() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.hadError
+}
+
+func (r *Reporter) HadRuntimeError() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.hadRuntimeError
+}
+
+func (r *Reporter) Reset() {
+	r.mu.Lock()
+	r.hadError = false
+	r.hadRuntimeError = false
+	r.mu.Unlock()
+}
+
+func (r *Reporter) Error(line int, where, message string) {
+	r.mu.Lock()
+	fmt.Fprintf(r.out, "[%d] Error %s: %s\n", line, where, message)
+	r.hadError = true
+	r.mu.Unlock()
+}
+
+func (r *Reporter) ParseError(err *Parse) {
+	if err.Token.Type == token.EOF {
+		r.Error(err.Token.Line, "at end", err.Message)
 	} else {
-		Error(t.Line, "at '"+t.Lexeme+"'", message)
+		r.Error(err.Token.Line, "at '"+err.Token.Lexeme+"'", err.Message)
 	}
-	panic(message)
+}
+
+func (r *Reporter) RuntimeError(err *Runtime) {
+	r.mu.Lock()
+	fmt.Fprintf(r.out, "[%d] Error : %s\n", err.Token.Line, err.Message)
+	r.hadRuntimeError = true
+	r.mu.Unlock()
+}
+
+type Parse struct {
+	Token   *token.Token
+	Message string
+}
+
+func (p *Parse) Error() string {
+	return p.Message
+}
+
+type Runtime struct {
+	Token   *token.Token
+	Message string
+}
+
+func (r *Runtime) Error() string {
+	return r.Message
+}
+
+func ParseError(t *token.Token, message string) {
+	panic(&Parse{t, message})
 }
 
 func RuntimeError(t *token.Token, message string) {
-	Error(t.Line, "", message)
-	HadRuntimeError = true
-	panic(message)
+	panic(&Runtime{t, message})
 }
@@ -0,0 +1,142 @@
+package loxtest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/interpreter"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/parser"
+	"github.com/iCiaran/golox/scanner"
+	"github.com/iCiaran/golox/token"
+)
+
+type Runner struct {
+	Filter *regexp.Regexp
+	Out    io.Writer
+	Setup  func(in *interpreter.Interpreter)
+	passed int
+	failed int
+}
+
+func (r *Runner) Run(paths ...string) (bool, error) {
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(path, "_test.lox") {
+				return nil
+			}
+			return r.runFile(path)
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if r.failed > 0 {
+		fmt.Fprintf(r.Out, "FAIL: %d of %d tests failed\n", r.failed, r.passed+r.failed)
+		return false, nil
+	}
+	fmt.Fprintf(r.Out, "ok: %d tests passed\n", r.passed)
+	return true, nil
+}
+
+func (r *Runner) runFile(path string) error {
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(r.Out, "=== %s\n", path)
+
+	var output bytes.Buffer
+	reporter := loxerror.NewReporter(&output)
+	statements := parser.NewParser(scanner.New(string(source), reporter).ScanTokens(), reporter).Parse()
+	if reporter.HadError() {
+		r.fail(path, 0, &output)
+		return nil
+	}
+
+	base := r.interpreter()
+	base.SetErrorOutput(&output)
+	if err := base.InterpretContext(context.Background(), statements); err != nil {
+		if _, ok := err.(*loxerror.Runtime); !ok {
+			fmt.Fprintln(&output, err)
+		}
+		r.fail(path, 0, &output)
+		return nil
+	}
+
+	for _, stmt := range statements {
+		function, ok := stmt.(*ast.Function)
+		if !ok || !strings.HasPrefix(function.Name.Lexeme, "test") {
+			continue
+		}
+		if r.Filter != nil && !r.Filter.MatchString(function.Name.Lexeme) {
+			continue
+		}
+		r.runTest(statements, function.Name)
+	}
+	return nil
+}
+
+func (r *Runner) runTest(statements []ast.Stmt, name *token.Token) {
+	var output bytes.Buffer
+	in := r.interpreter()
+	in.SetErrorOutput(&output)
+
+	// Each test starts from a fresh copy of the file's top-level state, so
+	// the top-level statements run again with their output discarded.
+	in.SetStdout(ioutil.Discard)
+	err := in.InterpretContext(context.Background(), statements)
+	in.SetStdout(r.Out)
+
+	var elapsed time.Duration
+	if err == nil {
+		in.SetScriptFrame(false)
+		paren := token.New(token.RIGHT_PAREN, ")", nil, name.Line, name.Column)
+		call := ast.NewExpression(ast.NewCall(ast.NewVariable(name), paren, nil))
+
+		start := time.Now()
+		err = in.InterpretContext(context.Background(), []ast.Stmt{call})
+		elapsed = time.Since(start)
+	}
+
+	if err == nil {
+		r.passed++
+		fmt.Fprintf(r.Out, "--- PASS: %s (%.2fs)\n", name.Lexeme, elapsed.Seconds())
+		return
+	}
+
+	if _, ok := err.(*loxerror.Runtime); !ok {
+		fmt.Fprintln(&output, err)
+	}
+	r.fail(name.Lexeme, elapsed, &output)
+}
+
+func (r *Runner) fail(name string, elapsed time.Duration, output *bytes.Buffer) {
+	r.failed++
+	fmt.Fprintf(r.Out, "--- FAIL: %s (%.2fs)\n", name, elapsed.Seconds())
+	for _, line := range strings.Split(strings.TrimRight(output.String(), "\n"), "\n") {
+		fmt.Fprintf(r.Out, "    %s\n", line)
+	}
+}
+
+func (r *Runner) interpreter() *interpreter.Interpreter {
+	in := interpreter.NewInterpreter()
+	in.SetStdout(r.Out)
+	if r.Setup != nil {
+		r.Setup(in)
+	}
+	return in
+}
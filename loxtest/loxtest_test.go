@@ -0,0 +1,135 @@
+package loxtest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var timing = regexp.MustCompile(` \(\d+\.\d+s\)`)
+
+var files = map[string]string{
+	"math_test.lox": `fun add(a, b) { return a + b; }
+fun testAdd() {
+  assertEqual(add(1, 2), 3);
+}
+fun testBroken() {
+  assertEqual(add(2, 2), 5);
+}
+fun helper() {}`,
+	"sub/state_test.lox": `var counter = 0;
+fun testFirst() { counter = counter + 1; assertEqual(counter, 1); }
+fun testSecond() { counter = counter + 1; assertEqual(counter, 1); }`,
+	"sub/shared_test.lox": `var c = chan(1);
+var t = spawn(() => 1);
+print "setup";
+fun testChannel() { c.send(1); assertEqual(c.recv(), 1); }
+fun testTask() { assertEqual(t.wait(), 1); }`,
+	"sub/syntax_test.lox": `fun testNothing() {`,
+	"sub/ignored.lox":     `fun testIgnored() { assert(false); }`,
+}
+
+func TestRunner(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "loxtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for name, source := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		ioutil.WriteFile(path, []byte(source), 0644)
+	}
+
+	tests := []struct {
+		filter string
+		passed bool
+		want   string
+	}{
+		{
+			passed: false,
+			want: `=== DIR/math_test.lox
+--- PASS: testAdd
+--- FAIL: testBroken
+    [6] Error : Expected 5 but got 4.
+    [line 6] in <native assertEqual>
+    [line 6] in <fn testBroken>
+=== DIR/sub/shared_test.lox
+setup
+--- PASS: testChannel
+--- PASS: testTask
+=== DIR/sub/state_test.lox
+--- PASS: testFirst
+--- PASS: testSecond
+=== DIR/sub/syntax_test.lox
+--- FAIL: DIR/sub/syntax_test.lox
+    [1] Error at end: Expect '}' after block.
+FAIL: 2 of 7 tests failed
+`,
+		},
+		{
+			filter: "Add|Second",
+			passed: false,
+			want: `=== DIR/math_test.lox
+--- PASS: testAdd
+=== DIR/sub/shared_test.lox
+setup
+=== DIR/sub/state_test.lox
+--- PASS: testSecond
+=== DIR/sub/syntax_test.lox
+--- FAIL: DIR/sub/syntax_test.lox
+    [1] Error at end: Expect '}' after block.
+FAIL: 1 of 3 tests failed
+`,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			var out bytes.Buffer
+			runner := &Runner{Out: &out}
+			if test.filter != "" {
+				runner.Filter = regexp.MustCompile(test.filter)
+			}
+
+			passed, err := runner.Run(dir)
+			assert.Nil(err)
+			assert.Equal(test.passed, passed)
+
+			got := strings.Replace(out.String(), dir, "DIR", -1)
+			got = timing.ReplaceAllString(got, "")
+			assert.Equal(test.want, got)
+		})
+	}
+}
+
+func TestRunnerPasses(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "loxtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "ok_test.lox"), []byte(`fun testOk() { print "hello"; }`), 0644)
+
+	var out bytes.Buffer
+	passed, err := (&Runner{Out: &out}).Run(dir)
+	assert.Nil(err)
+	assert.True(passed)
+	got := timing.ReplaceAllString(out.String(), "")
+	assert.Equal("=== "+filepath.Join(dir, "ok_test.lox")+"\nhello\n--- PASS: testOk\nok: 1 tests passed\n", got)
+
+	_, err = (&Runner{Out: &out}).Run(filepath.Join(dir, "missing"))
+	assert.NotNil(err)
+}
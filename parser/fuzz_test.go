@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func normalise(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		switch n := v.Interface().(type) {
+		case *token.Token:
+			n.Line, n.Column = 0, 0
+			return
+		case *ast.Literal:
+			switch value := n.Value.(type) {
+			case *big.Int:
+				n.Value = value.String() + "n"
+			case *big.Rat:
+				n.Value = value.RatString() + "m"
+			}
+			return
+		}
+		normalise(v.Elem())
+	case reflect.Interface:
+		normalise(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			normalise(v.Field(i))
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			normalise(v.Index(i))
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{
+			input: "print 1 + 2 * (3 - -4);",
+			want:  "print 1 + 2 * (3 - -4);\n",
+		},
+		{
+			input: "for (var i = 0; i < 3; i++) print i;",
+			want:  "{\n  var i = 0;\n  for (; i < 3;) {\n    print i;\n    i++;\n  }\n}\n",
+		},
+		{
+			input: "var a = 1; a += 2; ++a; a -= 1; - -a;",
+			want:  "var a = 1;\na += 2;\n++a;\n--a;\n- -a;\n",
+		},
+		{
+			input: "var f = (a, b) => a + b; var g = fun () { yield 1; };",
+			want:  "var f = (a, b) => a + b;\nvar g = fun () {\n  yield 1;\n};\n",
+		},
+		{
+			input: "print [1.0, 2n, 0.5m, 3m, \"s\", nil, {1: true}][0];",
+			want:  "print [1.0, 2n, 0.5m, 3m, \"s\", nil, {1: true}][0];\n",
+		},
+		{
+			input: "for (x in l) if (x) print x; else while (false) {}",
+			want:  "for (x in l) if (x) print x; else while (false) {}\n",
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			statements, errors := parse(test.input)
+			assert.Empty(errors)
+
+			formatted := ast.Format(statements)
+			assert.Equal(test.want, formatted)
+
+			reparsed, errors := parse(formatted)
+			assert.Empty(errors)
+			normalise(reflect.ValueOf(statements))
+			normalise(reflect.ValueOf(reparsed))
+			assert.Equal(statements, reparsed)
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	seeds, err := filepath.Glob("../testdata/*/*.lox")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, seed := range seeds {
+		source, err := ioutil.ReadFile(seed)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(source))
+	}
+
+	f.Fuzz(func(t *testing.T, source string) {
+		statements, errors := parse(source)
+		if errors != "" {
+			return
+		}
+
+		formatted := ast.Format(statements)
+		reparsed, errors := parse(formatted)
+		if errors != "" {
+			t.Fatalf("formatted source does not parse:\n%s\n%s", formatted, errors)
+		}
+
+		normalise(reflect.ValueOf(statements))
+		normalise(reflect.ValueOf(reparsed))
+		if !reflect.DeepEqual(statements, reparsed) {
+			t.Fatalf("round trip changed the AST:\n%s", formatted)
+		}
+	})
+}
@@ -7,12 +7,19 @@ import (
 )
 
 type Parser struct {
-	Tokens  []*token.Token
-	Current int
+	Tokens    []*token.Token
+	Current   int
+	functions []*functionScope
+	reporter  *loxerror.Reporter
 }
 
-func NewParser(tokens []*token.Token) *Parser {
-	return &Parser{tokens, 0}
+type functionScope struct {
+	generator bool
+	returns   []*token.Token
+}
+
+func NewParser(tokens []*token.Token, reporter *loxerror.Reporter) *Parser {
+	return &Parser{Tokens: tokens, reporter: reporter}
 }
 
 func (p *Parser) Parse() []ast.Stmt {
@@ -23,8 +30,38 @@ func (p *Parser) Parse() []ast.Stmt {
 	return statements
 }
 
+func (p *Parser) ParseExpression() (expr ast.Expr) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(*loxerror.Parse)
+			if !ok {
+				panic(r)
+			}
+			p.reporter.ParseError(err)
+			expr = nil
+		}
+	}()
+
+	expr = p.expression()
+	if !p.isAtEnd() {
+		loxerror.ParseError(p.peek(), "Expect end of expression.")
+	}
+	return expr
+}
+
 func (p *Parser) expression() ast.Expr {
-	return p.assignment()
+	return p.comma()
+}
+
+func (p *Parser) comma() ast.Expr {
+	expr := p.assignment()
+
+	for p.match(token.COMMA) {
+		operator := p.previous()
+		right := p.assignment()
+		expr = ast.NewBinary(expr, operator, right)
+	}
+	return expr
 }
 
 func (p *Parser) and() ast.Expr {
@@ -39,27 +76,52 @@ func (p *Parser) and() ast.Expr {
 }
 
 func (p *Parser) assignment() ast.Expr {
-	expr := p.or()
+	expr := p.conditional()
 	if p.match(token.EQUAL) {
 		equals := p.previous()
 		value := p.assignment()
 
-		if val, ok := expr.(*ast.Variable); ok {
-			return ast.NewAssign(val.Name, value)
+		switch target := expr.(type) {
+		case *ast.Variable:
+			return ast.NewAssign(target.Name, value)
+		case *ast.Index:
+			return ast.NewSetIndex(target.Object, target.Bracket, target.Index, value)
 		}
 		loxerror.ParseError(equals, "Invalid assignment target.")
 	}
+	if p.match(token.PLUS_EQUAL, token.MINUS_EQUAL, token.STAR_EQUAL, token.SLASH_EQUAL) {
+		operator := p.previous()
+		value := p.assignment()
+		return p.update(expr, operator, value, false)
+	}
+	return expr
+}
+
+func (p *Parser) conditional() ast.Expr {
+	expr := p.or()
+	if p.match(token.QUESTION) {
+		thenBranch := p.expression()
+		p.consume(token.COLON, "Expect ':' after then branch of conditional expression.")
+		elseBranch := p.conditional()
+		return ast.NewConditional(expr, thenBranch, elseBranch)
+	}
 	return expr
 }
 
 func (p *Parser) declaration() ast.Stmt {
 	defer func() {
 		if r := recover(); r != nil {
+			err, ok := r.(*loxerror.Parse)
+			if !ok {
+				panic(r)
+			}
+			p.reporter.ParseError(err)
 			p.synchronise()
 		}
 	}()
 
-	if p.match(token.FUN) {
+	if p.check(token.FUN) && p.checkNext(token.IDENTIFIER) {
+		p.advance()
 		return p.function("function")
 	}
 	if p.match(token.VAR) {
@@ -79,8 +141,48 @@ func (p *Parser) equality() ast.Expr {
 }
 
 func (p *Parser) comparison() ast.Expr {
-	expr := p.addition()
+	expr := p.bitwiseOr()
 	for p.match(token.GREATER, token.GREATER_EQUAL, token.LESS, token.LESS_EQUAL) {
+		operator := p.previous()
+		right := p.bitwiseOr()
+		expr = ast.NewBinary(expr, operator, right)
+	}
+	return expr
+}
+
+func (p *Parser) bitwiseOr() ast.Expr {
+	expr := p.bitwiseXor()
+	for p.match(token.PIPE) {
+		operator := p.previous()
+		right := p.bitwiseXor()
+		expr = ast.NewBinary(expr, operator, right)
+	}
+	return expr
+}
+
+func (p *Parser) bitwiseXor() ast.Expr {
+	expr := p.bitwiseAnd()
+	for p.match(token.CARET) {
+		operator := p.previous()
+		right := p.bitwiseAnd()
+		expr = ast.NewBinary(expr, operator, right)
+	}
+	return expr
+}
+
+func (p *Parser) bitwiseAnd() ast.Expr {
+	expr := p.shift()
+	for p.match(token.AMPERSAND) {
+		operator := p.previous()
+		right := p.shift()
+		expr = ast.NewBinary(expr, operator, right)
+	}
+	return expr
+}
+
+func (p *Parser) shift() ast.Expr {
+	expr := p.addition()
+	for p.match(token.LESS_LESS, token.GREATER_GREATER) {
 		operator := p.previous()
 		right := p.addition()
 		expr = ast.NewBinary(expr, operator, right)
@@ -100,7 +202,7 @@ func (p *Parser) addition() ast.Expr {
 
 func (p *Parser) multiplication() ast.Expr {
 	expr := p.unary()
-	for p.match(token.SLASH, token.STAR) {
+	for p.match(token.SLASH, token.STAR, token.PERCENT, token.TILDE_SLASH) {
 		operator := p.previous()
 		right := p.unary()
 		expr = ast.NewBinary(expr, operator, right)
@@ -121,12 +223,59 @@ func (p *Parser) or() ast.Expr {
 }
 
 func (p *Parser) unary() ast.Expr {
-	if p.match(token.BANG, token.MINUS) {
+	if p.match(token.BANG, token.MINUS, token.TILDE) {
 		operator := p.previous()
 		right := p.unary()
 		return ast.NewUnary(operator, right)
 	}
-	return p.call()
+	if p.match(token.PLUS_PLUS, token.MINUS_MINUS) {
+		operator := p.previous()
+		target := p.unary()
+		return p.update(target, operator, ast.NewLiteral(int64(1)), false)
+	}
+	return p.exponent()
+}
+
+func (p *Parser) exponent() ast.Expr {
+	expr := p.postfix()
+	if p.match(token.STAR_STAR) {
+		operator := p.previous()
+		right := p.unary()
+		return ast.NewBinary(expr, operator, right)
+	}
+	return expr
+}
+
+func (p *Parser) postfix() ast.Expr {
+	expr := p.call()
+	if p.match(token.PLUS_PLUS, token.MINUS_MINUS) {
+		operator := p.previous()
+		return p.update(expr, operator, ast.NewLiteral(int64(1)), true)
+	}
+	return expr
+}
+
+func (p *Parser) update(target ast.Expr, operator *token.Token, value ast.Expr, postfix bool) ast.Expr {
+	switch target.(type) {
+	case *ast.Variable, *ast.Index:
+	default:
+		loxerror.ParseError(operator, "Invalid assignment target.")
+	}
+
+	var t token.Type
+	switch operator.Type {
+	case token.PLUS_EQUAL, token.PLUS_PLUS:
+		t = token.PLUS
+	case token.MINUS_EQUAL, token.MINUS_MINUS:
+		t = token.MINUS
+	case token.STAR_EQUAL:
+		t = token.STAR
+	case token.SLASH_EQUAL:
+		t = token.SLASH
+	}
+
+	binary := token.New(t, operator.Lexeme[:1], nil, operator.Line, operator.Column)
+	return ast.NewUpdate(target, binary, value, postfix)
 }
 
 func (p *Parser) call() ast.Expr {
@@ -135,6 +284,13 @@ func (p *Parser) call() ast.Expr {
 	for {
 		if p.match(token.LEFT_PAREN) {
 			expr = p.finishCall(expr)
+		} else if p.match(token.DOT) {
+			name := p.consume(token.IDENTIFIER, "Expect property name after '.'.")
+			expr = ast.NewGet(expr, name)
+		} else if p.match(token.LEFT_BRACKET) {
+			index := p.expression()
+			bracket := p.consume(token.RIGHT_BRACKET, "Expect ']' after index.")
+			expr = ast.NewIndex(expr, bracket, index)
 		} else {
 			break
 		}
@@ -150,7 +306,7 @@ func (p *Parser) finishCall(callee ast.Expr) ast.Expr {
 			if len(arguments) > 255 {
 				loxerror.ParseError(p.peek(), "Cannot have more than 255 arguments.")
 			}
-			arguments = append(arguments, p.expression())
+			arguments = append(arguments, p.assignment())
 			if !p.match(token.COMMA) {
 				break
 			}
@@ -171,6 +327,14 @@ func (p *Parser) primary() ast.Expr {
 		return ast.NewLiteral(nil)
 	case p.match(token.NUMBER) || p.match(token.STRING):
 		return ast.NewLiteral(p.previous().Literal)
+	case p.match(token.LEFT_BRACKET):
+		return p.list()
+	case p.match(token.LEFT_BRACE):
+		return p.mapLiteral()
+	case p.match(token.FUN):
+		return p.lambda()
+	case p.isArrow():
+		return p.arrow()
 	case p.match(token.LEFT_PAREN):
 		expr := p.expression()
 		p.consume(token.RIGHT_PAREN, "Expect ')' after expression.")
@@ -183,6 +347,39 @@ func (p *Parser) primary() ast.Expr {
 	return nil
 }
 
+func (p *Parser) list() ast.Expr {
+	bracket := p.previous()
+	elements := make([]ast.Expr, 0)
+
+	for !p.check(token.RIGHT_BRACKET) {
+		elements = append(elements, p.assignment())
+		if !p.match(token.COMMA) {
+			break
+		}
+	}
+
+	p.consume(token.RIGHT_BRACKET, "Expect ']' after list elements.")
+	return ast.NewList(bracket, elements)
+}
+
+func (p *Parser) mapLiteral() ast.Expr {
+	brace := p.previous()
+	keys := make([]ast.Expr, 0)
+	values := make([]ast.Expr, 0)
+
+	for !p.check(token.RIGHT_BRACE) {
+		keys = append(keys, p.assignment())
+		p.consume(token.COLON, "Expect ':' after map key.")
+		values = append(values, p.assignment())
+		if !p.match(token.COMMA) {
+			break
+		}
+	}
+
+	p.consume(token.RIGHT_BRACE, "Expect '}' after map entries.")
+	return ast.NewMap(brace, keys, values)
+}
+
 func (p *Parser) block() []ast.Stmt {
 	statements := make([]ast.Stmt, 0)
 	for !p.check(token.RIGHT_BRACE) && !p.isAtEnd() {
@@ -208,6 +405,9 @@ func (p *Parser) statement() ast.Stmt {
 	if p.match(token.RETURN) {
 		return p.returnStatement()
 	}
+	if p.match(token.YIELD) {
+		return p.yieldStatement()
+	}
 	if p.match(token.LEFT_BRACE) {
 		return ast.NewBlock(p.block())
 	}
@@ -215,22 +415,48 @@ func (p *Parser) statement() ast.Stmt {
 }
 
 func (p *Parser) printStatement() ast.Stmt {
+	keyword := p.previous()
 	value := p.expression()
 	p.consume(token.SEMICOLON, "Expect ';' after value.")
-	return ast.NewPrint(value)
+	return ast.NewPrint(keyword, value)
 }
 
 func (p *Parser) returnStatement() ast.Stmt {
 	keyword := p.previous()
+	if len(p.functions) == 0 {
+		loxerror.ParseError(keyword, "Cannot return from top-level code.")
+	}
+
 	var value ast.Expr
 	if !p.check(token.SEMICOLON) {
 		value = p.expression()
 	}
 
 	p.consume(token.SEMICOLON, "Expect ';' after return value.")
+
+	if value != nil {
+		scope := p.functions[len(p.functions)-1]
+		scope.returns = append(scope.returns, keyword)
+	}
 	return ast.NewReturn(keyword, value)
 }
 
+func (p *Parser) yieldStatement() ast.Stmt {
+	keyword := p.previous()
+	if len(p.functions) == 0 {
+		loxerror.ParseError(keyword, "Cannot yield outside of a function.")
+	}
+	p.functions[len(p.functions)-1].generator = true
+
+	var value ast.Expr
+	if !p.check(token.SEMICOLON) {
+		value = p.expression()
+	}
+
+	p.consume(token.SEMICOLON, "Expect ';' after yield value.")
+	return ast.NewYield(keyword, value)
+}
+
 func (p *Parser) expressionStatement() ast.Stmt {
 	expr := p.expression()
 	p.consume(token.SEMICOLON, "Expect ';' after value.")
@@ -238,6 +464,7 @@ func (p *Parser) expressionStatement() ast.Stmt {
 }
 
 func (p *Parser) ifStatement() ast.Stmt {
+	keyword := p.previous()
 	p.consume(token.LEFT_PAREN, "Expect '(' after 'if'.")
 	condition := p.expression()
 	p.consume(token.RIGHT_PAREN, "Expect ')' after if condition.")
@@ -249,21 +476,27 @@ func (p *Parser) ifStatement() ast.Stmt {
 		elseBranch = p.statement()
 	}
 
-	return ast.NewIf(condition, thenBranch, elseBranch)
+	return ast.NewIf(keyword, condition, thenBranch, elseBranch)
 }
 
 func (p *Parser) whileStatement() ast.Stmt {
+	keyword := p.previous()
 	p.consume(token.LEFT_PAREN, "Expect '(' after 'while'.")
 	condition := p.expression()
 	p.consume(token.RIGHT_PAREN, "Expect ')' after if condition.")
 
 	body := p.statement()
-	return ast.NewWhile(condition, body)
+	return ast.NewWhile(keyword, condition, body)
 }
 
 func (p *Parser) forStatement() ast.Stmt {
+	keyword := p.previous()
 	p.consume(token.LEFT_PAREN, "Expect '(' after 'for'.")
 
+	if p.isForIn() {
+		return p.forInStatement()
+	}
+
 	var initializer ast.Stmt
 	if p.match(token.VAR) {
 		initializer = p.varDeclaration()
@@ -296,7 +529,7 @@ func (p *Parser) forStatement() ast.Stmt {
 		condition = ast.NewLiteral(true)
 	}
 
-	body = ast.NewWhile(condition, body)
+	body = ast.NewWhile(keyword, condition, body)
 
 	if initializer != nil {
 		body = ast.NewBlock([]ast.Stmt{
@@ -308,6 +541,25 @@ func (p *Parser) forStatement() ast.Stmt {
 	return body
 }
 
+func (p *Parser) forInStatement() ast.Stmt {
+	p.match(token.VAR)
+	name := p.consume(token.IDENTIFIER, "Expect variable name.")
+	p.consume(token.IN, "Expect 'in' after variable name.")
+	iterable := p.expression()
+	p.consume(token.RIGHT_PAREN, "Expect ')' after for clauses.")
+
+	body := p.statement()
+	return ast.NewForIn(name, iterable, body)
+}
+
+func (p *Parser) isForIn() bool {
+	n := p.Current
+	if p.Tokens[n].Type == token.VAR {
+		n++
+	}
+	return p.Tokens[n].Type == token.IDENTIFIER && p.Tokens[n+1].Type == token.IN
+}
+
 func (p *Parser) varDeclaration() ast.Stmt {
 	name := p.consume(token.IDENTIFIER, "Expect variable name.")
 
@@ -325,6 +577,55 @@ func (p *Parser) function(kind string) ast.Stmt {
 	name := p.consume(token.IDENTIFIER, "Expect "+kind+" name.")
 
 	p.consume(token.LEFT_PAREN, "Expect '(' after "+kind+" name.")
+	parameters := p.parameters()
+
+	p.consume(token.LEFT_BRACE, "Expect '{' before "+kind+" body.")
+	body, generator := p.functionBody()
+
+	return ast.NewFunction(name, parameters, body, generator)
+}
+
+func (p *Parser) lambda() ast.Expr {
+	keyword := p.previous()
+
+	p.consume(token.LEFT_PAREN, "Expect '(' after 'fun'.")
+	parameters := p.parameters()
+
+	p.consume(token.LEFT_BRACE, "Expect '{' before lambda body.")
+	body, generator := p.functionBody()
+
+	return ast.NewLambda(keyword, parameters, body, generator)
+}
+
+func (p *Parser) arrow() ast.Expr {
+	p.consume(token.LEFT_PAREN, "Expect '(' before parameters.")
+	parameters := p.parameters()
+	keyword := p.consume(token.ARROW, "Expect '=>' after parameters.")
+
+	if p.match(token.LEFT_BRACE) {
+		body, generator := p.functionBody()
+		return ast.NewLambda(keyword, parameters, body, generator)
+	}
+
+	body := []ast.Stmt{ast.NewReturn(keyword, p.assignment())}
+	return ast.NewLambda(keyword, parameters, body, false)
+}
+
+func (p *Parser) functionBody() ([]ast.Stmt, bool) {
+	scope := &functionScope{}
+	p.functions = append(p.functions, scope)
+	defer func() {
+		p.functions = p.functions[:len(p.functions)-1]
+	}()
+
+	body := p.block()
+	if scope.generator && len(scope.returns) > 0 {
+		loxerror.ParseError(scope.returns[0], "Cannot return a value from a generator.")
+	}
+	return body, scope.generator
+}
+
+func (p *Parser) parameters() []*token.Token {
 	parameters := make([]*token.Token, 0)
 
 	if !p.check(token.RIGHT_PAREN) {
@@ -337,10 +638,29 @@ func (p *Parser) function(kind string) ast.Stmt {
 	}
 	p.consume(token.RIGHT_PAREN, "Expect ')' after parameters.")
 
-	p.consume(token.LEFT_BRACE, "Expect '{' before "+kind+" body.")
-	body := p.block()
+	return parameters
+}
+
+func (p *Parser) isArrow() bool {
+	if !p.check(token.LEFT_PAREN) {
+		return false
+	}
+
+	n := p.Current + 1
+	if p.Tokens[n].Type != token.RIGHT_PAREN {
+		for {
+			if p.Tokens[n].Type != token.IDENTIFIER {
+				return false
+			}
+			n++
+			if p.Tokens[n].Type != token.COMMA {
+				break
+			}
+			n++
+		}
+	}
 
-	return ast.NewFunction(name, parameters, body)
+	return p.Tokens[n].Type == token.RIGHT_PAREN && p.Tokens[n+1].Type == token.ARROW
 }
 
 func (p *Parser) advance() *token.Token {
@@ -357,6 +677,13 @@ func (p *Parser) check(t token.Type) bool {
 	return p.peek().Type == t
 }
 
+func (p *Parser) checkNext(t token.Type) bool {
+	if p.isAtEnd() {
+		return false
+	}
+	return p.Tokens[p.Current+1].Type == t
+}
+
 func (p *Parser) consume(tokenType token.Type, message string) *token.Token {
 	if p.check(tokenType) {
 		return p.advance()
@@ -412,6 +739,8 @@ func (p *Parser) synchronise() {
 		case token.PRINT:
 			fallthrough
 		case token.RETURN:
+			fallthrough
+		case token.YIELD:
 			return
 		}
 
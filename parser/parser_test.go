@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func parse(source string) ([]ast.Stmt, string) {
+	var out bytes.Buffer
+	reporter := loxerror.NewReporter(&out)
+	statements := NewParser(scanner.New(source, reporter).ScanTokens(), reporter).Parse()
+	return statements, out.String()
+}
+
+func TestInvalidAssignmentTargets(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "1 = 2;", want: "[1] Error at '=': Invalid assignment target.\n"},
+		{input: "1 += 1;", want: "[1] Error at '+=': Invalid assignment target.\n"},
+		{input: "math.pi = 3;", want: "[1] Error at '=': Invalid assignment target.\n"},
+		{input: "math.pi += 1;", want: "[1] Error at '+=': Invalid assignment target.\n"},
+		{input: "f() -= 1;", want: "[1] Error at '-=': Invalid assignment target.\n"},
+		{input: "1++;", want: "[1] Error at '++': Invalid assignment target.\n"},
+		{input: "--f();", want: "[1] Error at '--': Invalid assignment target.\n"},
+		{input: "--math.pi;", want: "[1] Error at '--': Invalid assignment target.\n"},
+		{input: "a += 1; l[0] *= 2; a++; --l[0];", want: ""},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			_, errors := parse(test.input)
+			assert.Equal(test.want, errors)
+		})
+	}
+}
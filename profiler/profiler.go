@@ -0,0 +1,337 @@
+package profiler
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/interpreter"
+)
+
+type Profiler struct {
+	mu       sync.Mutex
+	filename string
+	now      func() time.Time
+	start    time.Time
+	duration time.Duration
+	states   map[*interpreter.Interpreter]*state
+	samples  map[string]*sample
+	order    []string
+}
+
+type location struct {
+	function string
+	line     int
+}
+
+type state struct {
+	last    time.Time
+	stack   []location
+	pending bool
+}
+
+type sample struct {
+	stack      []location
+	statements int64
+	calls      int64
+	nanos      int64
+}
+
+func New(filename string) *Profiler {
+	return newProfiler(filename, time.Now)
+}
+
+func newProfiler(filename string, now func() time.Time) *Profiler {
+	return &Profiler{
+		filename: filename,
+		now:      now,
+		start:    now(),
+		states:   make(map[*interpreter.Interpreter]*state),
+		samples:  make(map[string]*sample),
+	}
+}
+
+func (p *Profiler) Statement(in *interpreter.Interpreter, stmt ast.Stmt, line int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	st := p.state(in)
+	st.stack = stack(in.StackTrace(line))
+	s := p.sample(st.stack)
+	s.statements++
+	if st.pending {
+		s.calls++
+		st.pending = false
+	}
+}
+
+func (p *Profiler) Call(in *interpreter.Interpreter, function interpreter.Callable, arguments []interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.state(in).pending = true
+}
+
+func (p *Profiler) Return(in *interpreter.Interpreter, function interpreter.Callable, value interface{}) {
+	p.exit(in)
+}
+
+func (p *Profiler) Unwind(in *interpreter.Interpreter, function interpreter.Callable, err error) {
+	p.exit(in)
+}
+
+func (p *Profiler) exit(in *interpreter.Interpreter) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	st := p.state(in)
+	if st.pending {
+		p.sample(stack(in.StackTrace(0))).calls++
+		st.pending = false
+	}
+}
+
+func (p *Profiler) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	for _, st := range p.states {
+		p.flush(st, now)
+	}
+	p.duration = now.Sub(p.start)
+}
+
+func (p *Profiler) state(in *interpreter.Interpreter) *state {
+	now := p.now()
+	st, ok := p.states[in]
+	if !ok {
+		st = &state{last: now}
+		p.states[in] = st
+	}
+	p.flush(st, now)
+	return st
+}
+
+func (p *Profiler) flush(st *state, now time.Time) {
+	if st.stack != nil {
+		p.sample(st.stack).nanos += int64(now.Sub(st.last))
+	}
+	st.last = now
+}
+
+func (p *Profiler) sample(stack []location) *sample {
+	parts := make([]string, len(stack))
+	for n, l := range stack {
+		parts[n] = fmt.Sprintf("%s:%d", l.function, l.line)
+	}
+	key := strings.Join(parts, ";")
+
+	s, ok := p.samples[key]
+	if !ok {
+		s = &sample{stack: stack}
+		p.samples[key] = s
+		p.order = append(p.order, key)
+	}
+	return s
+}
+
+func stack(frames []interpreter.Frame) []location {
+	locations := make([]location, len(frames))
+	for n, frame := range frames {
+		locations[n] = location{functionName(frame.Name), frame.Line}
+	}
+	return locations
+}
+
+func functionName(name string) string {
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "<"), ">")
+	if name == "fn" {
+		return "lambda"
+	}
+	return strings.TrimPrefix(name, "fn ")
+}
+
+func (p *Profiler) WriteProfile(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	indices := map[string]int64{"": 0}
+	table := []string{""}
+	index := func(s string) int64 {
+		if n, ok := indices[s]; ok {
+			return n
+		}
+		indices[s] = int64(len(table))
+		table = append(table, s)
+		return indices[s]
+	}
+
+	var e encoder
+	for _, t := range [][2]string{{"statements", "count"}, {"calls", "count"}, {"time", "nanoseconds"}} {
+		e.message(1, func(e *encoder) {
+			e.int64(1, index(t[0]))
+			e.int64(2, index(t[1]))
+		})
+	}
+
+	functions := make(map[string]uint64)
+	locations := make(map[location]uint64)
+	var functionOrder []string
+	var locationOrder []location
+	for _, key := range p.order {
+		s := p.samples[key]
+		ids := make([]uint64, len(s.stack))
+		for n, l := range s.stack {
+			if _, ok := functions[l.function]; !ok {
+				functions[l.function] = uint64(len(functions) + 1)
+				functionOrder = append(functionOrder, l.function)
+			}
+			if _, ok := locations[l]; !ok {
+				locations[l] = uint64(len(locations) + 1)
+				locationOrder = append(locationOrder, l)
+			}
+			ids[n] = locations[l]
+		}
+
+		e.message(2, func(e *encoder) {
+			e.packed(1, ids)
+			e.packed(2, []uint64{uint64(s.statements), uint64(s.calls), uint64(s.nanos)})
+		})
+	}
+
+	for _, l := range locationOrder {
+		e.message(4, func(e *encoder) {
+			e.uint64(1, locations[l])
+			e.message(4, func(e *encoder) {
+				e.uint64(1, functions[l.function])
+				e.int64(2, int64(l.line))
+			})
+		})
+	}
+
+	for _, f := range functionOrder {
+		e.message(5, func(e *encoder) {
+			e.uint64(1, functions[f])
+			e.int64(2, index(f))
+			e.int64(3, index(f))
+			e.int64(4, index(p.filename))
+		})
+	}
+
+	timeIndex := index("time")
+	for _, s := range table {
+		e.string(6, s)
+	}
+	e.int64(9, p.start.UnixNano())
+	e.int64(10, int64(p.duration))
+	e.int64(14, timeIndex)
+
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write(e.data); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+type functionRow struct {
+	name  string
+	calls int64
+	flat  int64
+	cum   int64
+}
+
+type lineRow struct {
+	location
+	statements int64
+	nanos      int64
+}
+
+func (p *Profiler) WriteReport(w io.Writer) error {
+	p.mu.Lock()
+	functions := make(map[string]*functionRow)
+	lines := make(map[location]*lineRow)
+	for _, key := range p.order {
+		s := p.samples[key]
+		seen := make(map[string]bool)
+		for n, l := range s.stack {
+			row, ok := functions[l.function]
+			if !ok {
+				row = &functionRow{name: l.function}
+				functions[l.function] = row
+			}
+			if n == 0 {
+				row.calls += s.calls
+				row.flat += s.nanos
+			}
+			if !seen[l.function] {
+				row.cum += s.nanos
+				seen[l.function] = true
+			}
+		}
+
+		if len(s.stack) > 0 && s.statements > 0 {
+			top := s.stack[0]
+			row, ok := lines[top]
+			if !ok {
+				row = &lineRow{location: top}
+				lines[top] = row
+			}
+			row.statements += s.statements
+			row.nanos += s.nanos
+		}
+	}
+	p.mu.Unlock()
+
+	functionRows := make([]*functionRow, 0, len(functions))
+	for _, row := range functions {
+		functionRows = append(functionRows, row)
+	}
+	sort.Slice(functionRows, func(a, b int) bool {
+		if functionRows[a].cum != functionRows[b].cum {
+			return functionRows[a].cum > functionRows[b].cum
+		}
+		return functionRows[a].name < functionRows[b].name
+	})
+
+	lineRows := make([]*lineRow, 0, len(lines))
+	for _, row := range lines {
+		lineRows = append(lineRows, row)
+	}
+	sort.Slice(lineRows, func(a, b int) bool {
+		if lineRows[a].nanos != lineRows[b].nanos {
+			return lineRows[a].nanos > lineRows[b].nanos
+		}
+		if lineRows[a].line != lineRows[b].line {
+			return lineRows[a].line < lineRows[b].line
+		}
+		return lineRows[a].function < lineRows[b].function
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Function\tCalls\tFlat\tCum\t")
+	for _, row := range functionRows {
+		fmt.Fprintf(tw, "%s\t%d\t%v\t%v\t\n", row.name, row.calls, duration(row.flat), duration(row.cum))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Line\tFunction\tStatements\tTime\t")
+	for _, row := range lineRows {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%v\t\n", row.line, row.function, row.statements, duration(row.nanos))
+	}
+	return tw.Flush()
+}
+
+func duration(nanos int64) time.Duration {
+	return time.Duration(nanos).Round(time.Microsecond)
+}
@@ -0,0 +1,142 @@
+package profiler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/iCiaran/golox/interpreter"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/parser"
+	"github.com/iCiaran/golox/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+const script = `fun add(a, b) {
+  var sum = a + b;
+  return sum;
+}
+fun noop() {}
+noop();
+var total = 0;
+for (var i = 0; i < 3; i++) {
+  total = add(total, i);
+}`
+
+func profile(t *testing.T, script string) *Profiler {
+	clock := time.Unix(0, 0)
+	p := newProfiler("add.lox", func() time.Time {
+		clock = clock.Add(time.Millisecond)
+		return clock
+	})
+
+	reporter := loxerror.NewReporter(ioutil.Discard)
+	statements := parser.NewParser(scanner.New(script, reporter).ScanTokens(), reporter).Parse()
+	in := interpreter.NewInterpreter()
+	in.SetTracer(p)
+	if err := in.InterpretContext(context.Background(), statements); err != nil {
+		t.Fatal(err)
+	}
+	p.Stop()
+	return p
+}
+
+func TestReport(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	assert.Nil(profile(t, script).WriteReport(&out))
+	assert.Equal(`  Function  Calls  Flat   Cum
+    script      0  17ms  26ms
+       add      3   9ms   9ms
+      noop      1    0s    0s
+
+  Line  Function  Statements  Time
+     3       add           3   6ms
+     9    script           3   6ms
+     8    script           5   5ms
+     2       add           3   3ms
+     6    script           1   3ms
+     1    script           1   1ms
+     5    script           1   1ms
+     7    script           1   1ms
+`, out.String())
+}
+
+func TestReportUnwind(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	assert.Nil(profile(t, `fun fail() { return nope; }
+fun check() { return assertThrows(fail); }
+for (var i = 0; i < 2; i++) check();`).WriteReport(&out))
+	assert.Equal(`             Function  Calls  Flat   Cum
+               script      0  10ms  20ms
+                check      2   4ms  10ms
+                 fail      2   6ms   6ms
+  native assertThrows      0    0s   6ms
+
+  Line  Function  Statements  Time
+     3    script           6   8ms
+     1      fail           2   6ms
+     2     check           2   4ms
+     1    script           1   1ms
+     2    script           1   1ms
+`, out.String())
+}
+
+func TestProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	assert.Nil(profile(t, script).WriteProfile(&out))
+
+	gz, err := gzip.NewReader(&out)
+	assert.Nil(err)
+	data, err := ioutil.ReadAll(gz)
+	assert.Nil(err)
+
+	fields := make(map[int]int)
+	var table []string
+	for len(data) > 0 {
+		key, n := varint(data)
+		data = data[n:]
+		field := int(key >> 3)
+		fields[field]++
+
+		switch key & 7 {
+		case 0:
+			_, n = varint(data)
+			data = data[n:]
+		case 2:
+			length, n := varint(data)
+			value := data[n : n+int(length)]
+			data = data[n+int(length):]
+			if field == 6 {
+				table = append(table, string(value))
+			}
+		default:
+			t.Fatalf("unexpected wire type %d", key&7)
+		}
+	}
+
+	assert.Equal(3, fields[1])
+	assert.Equal(9, fields[2])
+	assert.Equal(9, fields[4])
+	assert.Equal(3, fields[5])
+	assert.Equal([]string{"", "statements", "count", "calls", "time", "nanoseconds", "script", "add.lox", "noop", "add"}, table)
+}
+
+func varint(data []byte) (uint64, int) {
+	var v uint64
+	for n, b := range data {
+		v |= uint64(b&0x7f) << (7 * uint(n))
+		if b < 0x80 {
+			return v, n + 1
+		}
+	}
+	return v, len(data)
+}
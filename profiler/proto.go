@@ -0,0 +1,53 @@
+package profiler
+
+type encoder struct {
+	data []byte
+}
+
+func (e *encoder) varint(v uint64) {
+	for v >= 0x80 {
+		e.data = append(e.data, byte(v)|0x80)
+		v >>= 7
+	}
+	e.data = append(e.data, byte(v))
+}
+
+func (e *encoder) tag(field, wireType int) {
+	e.varint(uint64(field)<<3 | uint64(wireType))
+}
+
+func (e *encoder) uint64(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+	e.tag(field, 0)
+	e.varint(v)
+}
+
+func (e *encoder) int64(field int, v int64) {
+	e.uint64(field, uint64(v))
+}
+
+func (e *encoder) bytes(field int, b []byte) {
+	e.tag(field, 2)
+	e.varint(uint64(len(b)))
+	e.data = append(e.data, b...)
+}
+
+func (e *encoder) string(field int, s string) {
+	e.bytes(field, []byte(s))
+}
+
+func (e *encoder) packed(field int, values []uint64) {
+	var packed encoder
+	for _, v := range values {
+		packed.varint(v)
+	}
+	e.bytes(field, packed.data)
+}
+
+func (e *encoder) message(field int, fn func(e *encoder)) {
+	var m encoder
+	fn(&m)
+	e.bytes(field, m.data)
+}
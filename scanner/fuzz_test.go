@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidUTF8(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input string
+		want  []*token.Token
+	}{
+		{
+			input: "a\xffb",
+			want: []*token.Token{
+				token.New(token.IDENTIFIER, "a", nil, 1, 1),
+				token.New(token.IDENTIFIER, "b", nil, 1, 3),
+				token.New(token.EOF, "", nil, 1, 4),
+			},
+		},
+		{
+			input: "1\xff",
+			want: []*token.Token{
+				token.New(token.NUMBER, "1", int64(1), 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "\"\xff\"",
+			want: []*token.Token{
+				token.New(token.STRING, "\"\xff\"", "\xff", 1, 1),
+				token.New(token.EOF, "", nil, 1, 4),
+			},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			sc := New(test.input, loxerror.NewReporter(ioutil.Discard))
+			got := sc.ScanTokens()
+			assert.Equal(test.want, got)
+		})
+	}
+}
+
+func FuzzScan(f *testing.F) {
+	seeds, err := filepath.Glob("../testdata/*/*.lox")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, seed := range seeds {
+		source, err := ioutil.ReadFile(seed)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(source))
+	}
+	f.Add("1.5m 0x_1 0b102 12n \"unterminated")
+
+	f.Fuzz(func(t *testing.T, source string) {
+		tokens := New(source, loxerror.NewReporter(ioutil.Discard)).ScanTokens()
+
+		if len(tokens) == 0 || tokens[len(tokens)-1].Type != token.EOF {
+			t.Fatalf("missing EOF token")
+		}
+		line := 1
+		for _, tok := range tokens {
+			if tok.Line < line {
+				t.Fatalf("token %q on line %d follows line %d", tok.Lexeme, tok.Line, line)
+			}
+			if tok.Column < 1 || tok.Column > utf8.RuneCountInString(source)+1 {
+				t.Fatalf("token %q has column %d", tok.Lexeme, tok.Column)
+			}
+			line = tok.Line
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"math/big"
 	"strconv"
 	"strings"
 	"unicode"
@@ -16,23 +17,46 @@ type Scanner struct {
 	start     int
 	current   int
 	line      int
+	lineStart int
+	startLine int
+	column    int
+	offset    int
+	runes     int
 	reader    *strings.Reader
-	lookahead []rune
+	lookahead []lookahead
+	reporter  *loxerror.Reporter
 }
 
-func New(source string) *Scanner {
-	return &Scanner{source, []*token.Token{}, 0, 0, 1, strings.NewReader(source), make([]rune, 0)}
+type lookahead struct {
+	value rune
+	size  int
+}
+
+func New(source string, reporter *loxerror.Reporter) *Scanner {
+	return &Scanner{source, []*token.Token{}, 0, 0, 1, 0, 1, 1, 0, 1, strings.NewReader(source), make([]lookahead, 0), reporter}
 }
 
 func (sc *Scanner) ScanTokens() []*token.Token {
 	for !sc.isAtEnd() {
 		sc.start = sc.current
+		sc.startLine = sc.line
+		sc.column = sc.columnAt(sc.start)
 		sc.scanToken()
 	}
-	sc.Tokens = append(sc.Tokens, token.New(token.EOF, "", nil, sc.line))
+	sc.column = sc.columnAt(sc.current)
+	sc.Tokens = append(sc.Tokens, token.New(token.EOF, "", nil, sc.line, sc.column))
 	return sc.Tokens
 }
 
+func (sc *Scanner) columnAt(offset int) int {
+	if sc.offset < sc.lineStart {
+		sc.offset, sc.runes = sc.lineStart, 1
+	}
+	sc.runes += utf8.RuneCountInString(sc.source[sc.offset:offset])
+	sc.offset = offset
+	return sc.runes
+}
+
 func (sc *Scanner) scanToken() {
 	c := sc.advance()
 	switch {
@@ -44,18 +68,58 @@ func (sc *Scanner) scanToken() {
 		sc.addToken(token.LEFT_BRACE, nil)
 	case c == '}':
 		sc.addToken(token.RIGHT_BRACE, nil)
+	case c == '[':
+		sc.addToken(token.LEFT_BRACKET, nil)
+	case c == ']':
+		sc.addToken(token.RIGHT_BRACKET, nil)
 	case c == ',':
 		sc.addToken(token.COMMA, nil)
 	case c == '.':
 		sc.addToken(token.DOT, nil)
 	case c == '-':
-		sc.addToken(token.MINUS, nil)
+		if sc.match('-') {
+			sc.addToken(token.MINUS_MINUS, nil)
+		} else if sc.match('=') {
+			sc.addToken(token.MINUS_EQUAL, nil)
+		} else {
+			sc.addToken(token.MINUS, nil)
+		}
 	case c == '+':
-		sc.addToken(token.PLUS, nil)
+		if sc.match('+') {
+			sc.addToken(token.PLUS_PLUS, nil)
+		} else if sc.match('=') {
+			sc.addToken(token.PLUS_EQUAL, nil)
+		} else {
+			sc.addToken(token.PLUS, nil)
+		}
 	case c == ';':
 		sc.addToken(token.SEMICOLON, nil)
 	case c == '*':
-		sc.addToken(token.STAR, nil)
+		if sc.match('*') {
+			sc.addToken(token.STAR_STAR, nil)
+		} else if sc.match('=') {
+			sc.addToken(token.STAR_EQUAL, nil)
+		} else {
+			sc.addToken(token.STAR, nil)
+		}
+	case c == '%':
+		sc.addToken(token.PERCENT, nil)
+	case c == '&':
+		sc.addToken(token.AMPERSAND, nil)
+	case c == '|':
+		sc.addToken(token.PIPE, nil)
+	case c == '^':
+		sc.addToken(token.CARET, nil)
+	case c == '~':
+		if sc.match('/') {
+			sc.addToken(token.TILDE_SLASH, nil)
+		} else {
+			sc.addToken(token.TILDE, nil)
+		}
+	case c == '?':
+		sc.addToken(token.QUESTION, nil)
+	case c == ':':
+		sc.addToken(token.COLON, nil)
 	case c == '!':
 		if sc.match('=') {
 			sc.addToken(token.BANG_EQUAL, nil)
@@ -65,18 +129,24 @@ func (sc *Scanner) scanToken() {
 	case c == '=':
 		if sc.match('=') {
 			sc.addToken(token.EQUAL_EQUAL, nil)
+		} else if sc.match('>') {
+			sc.addToken(token.ARROW, nil)
 		} else {
 			sc.addToken(token.EQUAL, nil)
 		}
 	case c == '<':
 		if sc.match('=') {
 			sc.addToken(token.LESS_EQUAL, nil)
+		} else if sc.match('<') {
+			sc.addToken(token.LESS_LESS, nil)
 		} else {
 			sc.addToken(token.LESS, nil)
 		}
 	case c == '>':
 		if sc.match('=') {
 			sc.addToken(token.GREATER_EQUAL, nil)
+		} else if sc.match('>') {
+			sc.addToken(token.GREATER_GREATER, nil)
 		} else {
 			sc.addToken(token.GREATER, nil)
 		}
@@ -85,21 +155,23 @@ func (sc *Scanner) scanToken() {
 			for sc.peek() != '\n' && !sc.isAtEnd() {
 				sc.advance()
 			}
+		} else if sc.match('=') {
+			sc.addToken(token.SLASH_EQUAL, nil)
 		} else {
 			sc.addToken(token.SLASH, nil)
 		}
 	case c == '"':
 		sc.scanString()
-	case unicode.IsDigit(c):
+	case isDigit(c, 10):
 		sc.number()
 	case isAlpha(c):
 		sc.identifier()
 	case c == ' ', c == '\r', c == '\t':
 		break
 	case c == '\n':
-		sc.line++
+		sc.newLine()
 	default:
-		loxerror.Error(sc.line, "", "Unexpected character.")
+		sc.reporter.Error(sc.line, "", "Unexpected character.")
 	}
 }
 
@@ -107,18 +179,26 @@ func (sc *Scanner) isAtEnd() bool {
 	return sc.current >= len(sc.source)
 }
 
-func (sc *Scanner) nextRune() (rune, int, error) {
+func (sc *Scanner) nextRune() (rune, int) {
 	if len(sc.lookahead) > 0 {
 		r := sc.lookahead[0]
 		sc.lookahead = sc.lookahead[1:]
-		return r, utf8.RuneLen(r), nil
+		return r.value, r.size
 	}
 
-	return sc.reader.ReadRune()
+	r, size, err := sc.reader.ReadRune()
+	if err != nil {
+		return '\000', 0
+	}
+	return r, size
+}
+
+func (sc *Scanner) unread(runes ...lookahead) {
+	sc.lookahead = append(runes, sc.lookahead...)
 }
 
 func (sc *Scanner) advance() rune {
-	ch, size, _ := sc.nextRune()
+	ch, size := sc.nextRune()
 	sc.current += size
 	return ch
 }
@@ -128,12 +208,9 @@ func (sc *Scanner) match(expected rune) bool {
 		return false
 	}
 
-	ch, size, err := sc.nextRune()
-	if err != nil {
-		loxerror.Error(sc.line, "", "Match at end of line.")
-	}
+	ch, size := sc.nextRune()
 	if ch != expected {
-		sc.lookahead = append(sc.lookahead, ch)
+		sc.unread(lookahead{ch, size})
 		return false
 	}
 
@@ -146,42 +223,31 @@ func (sc *Scanner) peek() rune {
 		return '\000'
 	}
 
-	ch, _, err := sc.nextRune()
-	if err != nil {
-		loxerror.Error(sc.line, "", "Peek loxerror.")
-	}
-
-	sc.lookahead = append(sc.lookahead, ch)
+	ch, size := sc.nextRune()
+	sc.unread(lookahead{ch, size})
 	return ch
 }
 
 func (sc *Scanner) peekNext() rune {
-	if sc.current+1 >= len(sc.source) {
+	if sc.isAtEnd() {
 		return '\000'
 	}
-	first, _, err := sc.nextRune()
-	if err != nil {
-		loxerror.Error(sc.line, "", "Peek next error.")
-	}
-	second, _, err := sc.nextRune()
-	if err != nil {
-		loxerror.Error(sc.line, "", "Peek next error.")
-	}
 
-	sc.lookahead = append(sc.lookahead, []rune{first, second}...)
+	first, firstSize := sc.nextRune()
+	second, secondSize := sc.nextRune()
+	sc.unread(lookahead{first, firstSize}, lookahead{second, secondSize})
 	return second
 }
 
 func (sc *Scanner) scanString() {
 	for sc.peek() != '"' && !sc.isAtEnd() {
-		if sc.peek() == '\n' {
-			sc.line++
+		if sc.advance() == '\n' {
+			sc.newLine()
 		}
-		sc.advance()
 	}
 
 	if sc.isAtEnd() {
-		loxerror.Error(sc.line-1, "", "Unterminated string.")
+		sc.reporter.Error(sc.line-1, "", "Unterminated string.")
 		return
 	}
 
@@ -192,25 +258,79 @@ func (sc *Scanner) scanString() {
 }
 
 func (sc *Scanner) number() {
-	for unicode.IsDigit(sc.peek()) {
-		sc.advance()
+	if sc.source[sc.start] == '0' {
+		if base, ok := bases[sc.peek()]; ok {
+			sc.advance()
+			if !isDigit(sc.peek(), base) {
+				sc.reporter.Error(sc.line, "", "Expect digits after base prefix.")
+				return
+			}
+			sc.digits(base)
+			sc.integer(sc.source[sc.start+2:sc.current], base)
+			return
+		}
 	}
 
-	if sc.peek() == '.' && unicode.IsDigit(sc.peekNext()) {
+	sc.digits(10)
+
+	if sc.peek() == '.' && isDigit(sc.peekNext(), 10) {
 		sc.advance()
-		for unicode.IsDigit(sc.peek()) {
-			sc.advance()
+		sc.digits(10)
+
+		text := strings.ReplaceAll(sc.source[sc.start:sc.current], "_", "")
+		if sc.suffix('m') {
+			num, _ := new(big.Rat).SetString(text)
+			sc.addToken(token.NUMBER, num)
+			return
+		}
+
+		num, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			sc.reporter.Error(sc.line, "", "Number format error.")
 		}
+		sc.addToken(token.NUMBER, num)
+		return
 	}
 
-	num, err := strconv.ParseFloat(sc.source[sc.start:sc.current], 64)
-	if err != nil {
-		loxerror.Error(sc.line, "", "Number format loxerror.")
+	sc.integer(sc.source[sc.start:sc.current], 10)
+}
+
+func (sc *Scanner) digits(base int) {
+	for isDigit(sc.peek(), base) || (sc.peek() == '_' && isDigit(sc.peekNext(), base)) {
+		sc.advance()
+	}
+}
+
+func (sc *Scanner) integer(text string, base int) {
+	text = strings.ReplaceAll(text, "_", "")
+
+	if sc.suffix('n') {
+		num, _ := new(big.Int).SetString(text, base)
+		sc.addToken(token.NUMBER, num)
+		return
+	}
+	if base == 10 && sc.suffix('m') {
+		num, _ := new(big.Rat).SetString(text)
+		sc.addToken(token.NUMBER, num)
+		return
 	}
 
+	num, err := strconv.ParseInt(text, base, 64)
+	if err != nil {
+		sc.reporter.Error(sc.line, "", "Integer literal too large.")
+		return
+	}
 	sc.addToken(token.NUMBER, num)
 }
 
+func (sc *Scanner) suffix(r rune) bool {
+	if sc.peek() == r && !isAlphanumeric(sc.peekNext()) {
+		sc.advance()
+		return true
+	}
+	return false
+}
+
 func (sc *Scanner) identifier() {
 	for isAlphanumeric(sc.peek()) {
 		sc.advance()
@@ -226,7 +346,30 @@ func (sc *Scanner) identifier() {
 
 func (sc *Scanner) addToken(tokenType token.Type, literal interface{}) {
 	text := sc.source[sc.start:sc.current]
-	sc.Tokens = append(sc.Tokens, token.New(tokenType, text, literal, sc.line))
+	sc.Tokens = append(sc.Tokens, token.New(tokenType, text, literal, sc.startLine, sc.column))
+}
+
+func (sc *Scanner) newLine() {
+	sc.line++
+	sc.lineStart = sc.current
+}
+
+var bases = map[rune]int{
+	'x': 16, 'X': 16,
+	'o': 8, 'O': 8,
+	'b': 2, 'B': 2,
+}
+
+func isDigit(r rune, base int) bool {
+	switch {
+	case r >= '0' && r <= '9':
+		return int(r-'0') < base
+	case r >= 'a' && r <= 'f':
+		return base == 16
+	case r >= 'A' && r <= 'F':
+		return base == 16
+	}
+	return false
 }
 
 func isAlphanumeric(r rune) bool {
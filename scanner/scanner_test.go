@@ -1,9 +1,13 @@
 package scanner
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"math/big"
 	"testing"
 
+	"github.com/iCiaran/golox/loxerror"
 	"github.com/iCiaran/golox/token"
 
 	"github.com/stretchr/testify/assert"
@@ -19,113 +23,127 @@ func TestKeywords(t *testing.T) {
 		{
 			input: "and",
 			want: []*token.Token{
-				token.New(token.AND, "and", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.AND, "and", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 4),
 			},
 		},
 		{
 			input: "class",
 			want: []*token.Token{
-				token.New(token.CLASS, "class", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.CLASS, "class", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 6),
 			},
 		},
 		{
 			input: "else",
 			want: []*token.Token{
-				token.New(token.ELSE, "else", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.ELSE, "else", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 5),
 			},
 		},
 		{
 			input: "false",
 			want: []*token.Token{
-				token.New(token.FALSE, "false", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.FALSE, "false", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 6),
 			},
 		},
 		{
 			input: "for",
 			want: []*token.Token{
-				token.New(token.FOR, "for", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.FOR, "for", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 4),
 			},
 		},
 		{
 			input: "fun",
 			want: []*token.Token{
-				token.New(token.FUN, "fun", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.FUN, "fun", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 4),
 			},
 		},
 		{
 			input: "if",
 			want: []*token.Token{
-				token.New(token.IF, "if", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.IF, "if", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
 			},
 		},
 		{
 			input: "nil",
 			want: []*token.Token{
-				token.New(token.NIL, "nil", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.NIL, "nil", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 4),
 			},
 		},
 		{
 			input: "or",
 			want: []*token.Token{
-				token.New(token.OR, "or", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.OR, "or", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
 			},
 		},
 		{
 			input: "return",
 			want: []*token.Token{
-				token.New(token.RETURN, "return", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.RETURN, "return", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 7),
 			},
 		},
 		{
 			input: "super",
 			want: []*token.Token{
-				token.New(token.SUPER, "super", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.SUPER, "super", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 6),
 			},
 		},
 		{
 			input: "this",
 			want: []*token.Token{
-				token.New(token.THIS, "this", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.THIS, "this", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 5),
 			},
 		},
 		{
 			input: "true",
 			want: []*token.Token{
-				token.New(token.TRUE, "true", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.TRUE, "true", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 5),
 			},
 		},
 		{
 			input: "var",
 			want: []*token.Token{
-				token.New(token.VAR, "var", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.VAR, "var", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 4),
 			},
 		},
 		{
 			input: "while",
 			want: []*token.Token{
-				token.New(token.WHILE, "while", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.WHILE, "while", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 6),
+			},
+		},
+		{
+			input: "in",
+			want: []*token.Token{
+				token.New(token.IN, "in", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "yield",
+			want: []*token.Token{
+				token.New(token.YIELD, "yield", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 6),
 			},
 		},
 	}
 
 	for i, test := range tests {
 		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
-			sc := New(test.input)
+			sc := New(test.input, loxerror.NewReporter(ioutil.Discard))
 			got := sc.ScanTokens()
 			assert.Equal(test.want, got)
 		})
@@ -142,85 +160,148 @@ func TestSingleCharacter(t *testing.T) {
 		{
 			input: "(",
 			want: []*token.Token{
-				token.New(token.LEFT_PAREN, "(", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.LEFT_PAREN, "(", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: ")",
 			want: []*token.Token{
-				token.New(token.RIGHT_PAREN, ")", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.RIGHT_PAREN, ")", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: "{",
 			want: []*token.Token{
-				token.New(token.LEFT_BRACE, "{", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.LEFT_BRACE, "{", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: "}",
 			want: []*token.Token{
-				token.New(token.RIGHT_BRACE, "}", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.RIGHT_BRACE, "}", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: ",",
 			want: []*token.Token{
-				token.New(token.COMMA, ",", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.COMMA, ",", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: ".",
 			want: []*token.Token{
-				token.New(token.DOT, ".", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.DOT, ".", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: "-",
 			want: []*token.Token{
-				token.New(token.MINUS, "-", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.MINUS, "-", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: "+",
 			want: []*token.Token{
-				token.New(token.PLUS, "+", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.PLUS, "+", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: ";",
 			want: []*token.Token{
-				token.New(token.SEMICOLON, ";", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.SEMICOLON, ";", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: "/",
 			want: []*token.Token{
-				token.New(token.SLASH, "/", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.SLASH, "/", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: "*",
 			want: []*token.Token{
-				token.New(token.STAR, "*", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.STAR, "*", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
+			},
+		},
+		{
+			input: "%",
+			want: []*token.Token{
+				token.New(token.PERCENT, "%", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
+			},
+		},
+		{
+			input: "?",
+			want: []*token.Token{
+				token.New(token.QUESTION, "?", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
+			},
+		},
+		{
+			input: ":",
+			want: []*token.Token{
+				token.New(token.COLON, ":", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
+			},
+		},
+		{
+			input: "&",
+			want: []*token.Token{
+				token.New(token.AMPERSAND, "&", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
+			},
+		},
+		{
+			input: "|",
+			want: []*token.Token{
+				token.New(token.PIPE, "|", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
+			},
+		},
+		{
+			input: "^",
+			want: []*token.Token{
+				token.New(token.CARET, "^", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
+			},
+		},
+		{
+			input: "~",
+			want: []*token.Token{
+				token.New(token.TILDE, "~", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
+			},
+		},
+		{
+			input: "[",
+			want: []*token.Token{
+				token.New(token.LEFT_BRACKET, "[", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
+			},
+		},
+		{
+			input: "]",
+			want: []*token.Token{
+				token.New(token.RIGHT_BRACKET, "]", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 	}
 
 	for i, test := range tests {
 		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
-			sc := New(test.input)
+			sc := New(test.input, loxerror.NewReporter(ioutil.Discard))
 			got := sc.ScanTokens()
 			assert.Equal(test.want, got)
 		})
@@ -237,64 +318,141 @@ func TestOneOrTwoCharacters(t *testing.T) {
 		{
 			input: "!",
 			want: []*token.Token{
-				token.New(token.BANG, "!", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.BANG, "!", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: "!=",
 			want: []*token.Token{
-				token.New(token.BANG_EQUAL, "!=", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.BANG_EQUAL, "!=", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
 			},
 		},
 		{
 			input: "=",
 			want: []*token.Token{
-				token.New(token.EQUAL, "=", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.EQUAL, "=", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: "==",
 			want: []*token.Token{
-				token.New(token.EQUAL_EQUAL, "==", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.EQUAL_EQUAL, "==", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "=>",
+			want: []*token.Token{
+				token.New(token.ARROW, "=>", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
 			},
 		},
 		{
 			input: ">",
 			want: []*token.Token{
-				token.New(token.GREATER, ">", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.GREATER, ">", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: ">=",
 			want: []*token.Token{
-				token.New(token.GREATER_EQUAL, ">=", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.GREATER_EQUAL, ">=", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
 			},
 		},
 		{
 			input: "<",
 			want: []*token.Token{
-				token.New(token.LESS, "<", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.LESS, "<", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: "<=",
 			want: []*token.Token{
-				token.New(token.LESS_EQUAL, "<=", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.LESS_EQUAL, "<=", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "**",
+			want: []*token.Token{
+				token.New(token.STAR_STAR, "**", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "*=",
+			want: []*token.Token{
+				token.New(token.STAR_EQUAL, "*=", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "+=",
+			want: []*token.Token{
+				token.New(token.PLUS_EQUAL, "+=", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "++",
+			want: []*token.Token{
+				token.New(token.PLUS_PLUS, "++", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "-=",
+			want: []*token.Token{
+				token.New(token.MINUS_EQUAL, "-=", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "--",
+			want: []*token.Token{
+				token.New(token.MINUS_MINUS, "--", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "/=",
+			want: []*token.Token{
+				token.New(token.SLASH_EQUAL, "/=", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "<<",
+			want: []*token.Token{
+				token.New(token.LESS_LESS, "<<", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: ">>",
+			want: []*token.Token{
+				token.New(token.GREATER_GREATER, ">>", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
+			},
+		},
+		{
+			input: "~/",
+			want: []*token.Token{
+				token.New(token.TILDE_SLASH, "~/", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
 			},
 		},
 	}
 
 	for i, test := range tests {
 		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
-			sc := New(test.input)
+			sc := New(test.input, loxerror.NewReporter(ioutil.Discard))
 			got := sc.ScanTokens()
 			assert.Equal(test.want, got)
 		})
@@ -312,14 +470,14 @@ func TestEmpty(t *testing.T) {
 		{
 			input: "",
 			want: []*token.Token{
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.EOF, "", nil, 1, 1),
 			},
 		},
 	}
 
 	for i, test := range tests {
 		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
-			sc := New(test.input)
+			sc := New(test.input, loxerror.NewReporter(ioutil.Discard))
 			got := sc.ScanTokens()
 			assert.Equal(test.want, got)
 		})
@@ -338,18 +496,28 @@ func TestWhiteSpace(t *testing.T) {
 			
 			end`,
 			want: []*token.Token{
-				token.New(token.IDENTIFIER, "space", nil, 1),
-				token.New(token.IDENTIFIER, "tabs", nil, 1),
-				token.New(token.IDENTIFIER, "newlines", nil, 1),
-				token.New(token.IDENTIFIER, "end", nil, 3),
-				token.New(token.EOF, "", nil, 3),
+				token.New(token.IDENTIFIER, "space", nil, 1, 1),
+				token.New(token.IDENTIFIER, "tabs", nil, 1, 9),
+				token.New(token.IDENTIFIER, "newlines", nil, 1, 14),
+				token.New(token.IDENTIFIER, "end", nil, 3, 4),
+				token.New(token.EOF, "", nil, 3, 7),
+			},
+		},
+		{
+			input: "\"é\" x \"a\nb\" y",
+			want: []*token.Token{
+				token.New(token.STRING, "\"é\"", "é", 1, 1),
+				token.New(token.IDENTIFIER, "x", nil, 1, 5),
+				token.New(token.STRING, "\"a\nb\"", "a\nb", 1, 7),
+				token.New(token.IDENTIFIER, "y", nil, 2, 4),
+				token.New(token.EOF, "", nil, 2, 5),
 			},
 		},
 	}
 
 	for i, test := range tests {
 		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
-			sc := New(test.input)
+			sc := New(test.input, loxerror.NewReporter(ioutil.Discard))
 			got := sc.ScanTokens()
 			assert.Equal(test.want, got)
 		})
@@ -366,40 +534,156 @@ func TestNumbers(t *testing.T) {
 		{
 			input: "123",
 			want: []*token.Token{
-				token.New(token.NUMBER, "123", float64(123), 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.NUMBER, "123", int64(123), 1, 1),
+				token.New(token.EOF, "", nil, 1, 4),
 			},
 		},
 		{
 			input: "123.456",
 			want: []*token.Token{
-				token.New(token.NUMBER, "123.456", float64(123.456), 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.NUMBER, "123.456", float64(123.456), 1, 1),
+				token.New(token.EOF, "", nil, 1, 8),
 			},
 		},
 		{
 			input: ".456",
 			want: []*token.Token{
-				token.New(token.DOT, ".", nil, 1),
-				token.New(token.NUMBER, "456", float64(456), 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.DOT, ".", nil, 1, 1),
+				token.New(token.NUMBER, "456", int64(456), 1, 2),
+				token.New(token.EOF, "", nil, 1, 5),
 			},
 		},
 		{
 			input: "123.",
 			want: []*token.Token{
-				token.New(token.NUMBER, "123", float64(123), 1),
-				token.New(token.DOT, ".", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.NUMBER, "123", int64(123), 1, 1),
+				token.New(token.DOT, ".", nil, 1, 4),
+				token.New(token.EOF, "", nil, 1, 5),
 			},
 		},
+		{
+			input: "0xff",
+			want: []*token.Token{
+				token.New(token.NUMBER, "0xff", int64(255), 1, 1),
+				token.New(token.EOF, "", nil, 1, 5),
+			},
+		},
+		{
+			input: "0b1010",
+			want: []*token.Token{
+				token.New(token.NUMBER, "0b1010", int64(10), 1, 1),
+				token.New(token.EOF, "", nil, 1, 7),
+			},
+		},
+		{
+			input: "0o17",
+			want: []*token.Token{
+				token.New(token.NUMBER, "0o17", int64(15), 1, 1),
+				token.New(token.EOF, "", nil, 1, 5),
+			},
+		},
+		{
+			input: "1_000_000",
+			want: []*token.Token{
+				token.New(token.NUMBER, "1_000_000", int64(1000000), 1, 1),
+				token.New(token.EOF, "", nil, 1, 10),
+			},
+		},
+		{
+			input: "1_000.000_5",
+			want: []*token.Token{
+				token.New(token.NUMBER, "1_000.000_5", float64(1000.0005), 1, 1),
+				token.New(token.EOF, "", nil, 1, 12),
+			},
+		},
+		{
+			input: "1__0",
+			want: []*token.Token{
+				token.New(token.NUMBER, "1", int64(1), 1, 1),
+				token.New(token.IDENTIFIER, "__0", nil, 1, 2),
+				token.New(token.EOF, "", nil, 1, 5),
+			},
+		},
+		{
+			input: "123n",
+			want: []*token.Token{
+				token.New(token.NUMBER, "123n", big.NewInt(123), 1, 1),
+				token.New(token.EOF, "", nil, 1, 5),
+			},
+		},
+		{
+			input: "0xffn",
+			want: []*token.Token{
+				token.New(token.NUMBER, "0xffn", big.NewInt(255), 1, 1),
+				token.New(token.EOF, "", nil, 1, 6),
+			},
+		},
+		{
+			input: "1.25m",
+			want: []*token.Token{
+				token.New(token.NUMBER, "1.25m", big.NewRat(5, 4), 1, 1),
+				token.New(token.EOF, "", nil, 1, 6),
+			},
+		},
+		{
+			input: "10m",
+			want: []*token.Token{
+				token.New(token.NUMBER, "10m", big.NewRat(10, 1), 1, 1),
+				token.New(token.EOF, "", nil, 1, 4),
+			},
+		},
+		{
+			input: "1nx",
+			want: []*token.Token{
+				token.New(token.NUMBER, "1", int64(1), 1, 1),
+				token.New(token.IDENTIFIER, "nx", nil, 1, 2),
+				token.New(token.EOF, "", nil, 1, 4),
+			},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			sc := New(test.input, loxerror.NewReporter(ioutil.Discard))
+			got := sc.ScanTokens()
+			assert.Equal(test.want, got)
+		})
+	}
+}
+
+func TestNonASCIIDigits(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input  string
+		want   []*token.Token
+		errors string
+	}{
+		{
+			input: "١",
+			want: []*token.Token{
+				token.New(token.EOF, "", nil, 1, 2),
+			},
+			errors: "[1] Error : Unexpected character.\n",
+		},
+		{
+			input: "1.٢",
+			want: []*token.Token{
+				token.New(token.NUMBER, "1", int64(1), 1, 1),
+				token.New(token.DOT, ".", nil, 1, 2),
+				token.New(token.EOF, "", nil, 1, 4),
+			},
+			errors: "[1] Error : Unexpected character.\n",
+		},
 	}
 
 	for i, test := range tests {
 		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
-			sc := New(test.input)
+			var errors bytes.Buffer
+			sc := New(test.input, loxerror.NewReporter(&errors))
 			got := sc.ScanTokens()
 			assert.Equal(test.want, got)
+			assert.Equal(test.errors, errors.String())
 		})
 	}
 }
@@ -414,22 +698,31 @@ func TestStrings(t *testing.T) {
 		{
 			input: `""`,
 			want: []*token.Token{
-				token.New(token.STRING, `""`, "", 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.STRING, `""`, "", 1, 1),
+				token.New(token.EOF, "", nil, 1, 3),
 			},
 		},
 		{
 			input: `"string"`,
 			want: []*token.Token{
-				token.New(token.STRING, `"string"`, "string", 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.STRING, `"string"`, "string", 1, 1),
+				token.New(token.EOF, "", nil, 1, 9),
+			},
+		},
+		{
+			input: "x \"multi\nline\" y",
+			want: []*token.Token{
+				token.New(token.IDENTIFIER, "x", nil, 1, 1),
+				token.New(token.STRING, "\"multi\nline\"", "multi\nline", 1, 3),
+				token.New(token.IDENTIFIER, "y", nil, 2, 7),
+				token.New(token.EOF, "", nil, 2, 8),
 			},
 		},
 	}
 
 	for i, test := range tests {
 		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
-			sc := New(test.input)
+			sc := New(test.input, loxerror.NewReporter(ioutil.Discard))
 			got := sc.ScanTokens()
 			assert.Equal(test.want, got)
 		})
@@ -446,50 +739,50 @@ func TestIdentifiers(t *testing.T) {
 		{
 			input: "ciaran",
 			want: []*token.Token{
-				token.New(token.IDENTIFIER, "ciaran", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.IDENTIFIER, "ciaran", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 7),
 			},
 		},
 		{
 			input: "_underscore",
 			want: []*token.Token{
-				token.New(token.IDENTIFIER, "_underscore", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.IDENTIFIER, "_underscore", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 12),
 			},
 		},
 		{
 			input: "middle_underscore",
 			want: []*token.Token{
-				token.New(token.IDENTIFIER, "middle_underscore", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.IDENTIFIER, "middle_underscore", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 18),
 			},
 		},
 		{
 			input: "_",
 			want: []*token.Token{
-				token.New(token.IDENTIFIER, "_", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.IDENTIFIER, "_", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 2),
 			},
 		},
 		{
 			input: "_123",
 			want: []*token.Token{
-				token.New(token.IDENTIFIER, "_123", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.IDENTIFIER, "_123", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 5),
 			},
 		},
 		{
 			input: "ab123",
 			want: []*token.Token{
-				token.New(token.IDENTIFIER, "ab123", nil, 1),
-				token.New(token.EOF, "", nil, 1),
+				token.New(token.IDENTIFIER, "ab123", nil, 1, 1),
+				token.New(token.EOF, "", nil, 1, 6),
 			},
 		},
 	}
 
 	for i, test := range tests {
 		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
-			sc := New(test.input)
+			sc := New(test.input, loxerror.NewReporter(ioutil.Discard))
 			got := sc.ScanTokens()
 			assert.Equal(test.want, got)
 		})
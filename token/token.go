@@ -9,10 +9,11 @@ type Token struct {
 	Lexeme  string
 	Literal interface{}
 	Line    int
+	Column  int
 }
 
-func New(tokenType Type, lexeme string, literal interface{}, line int) *Token {
-	return &Token{tokenType, lexeme, literal, line}
+func New(tokenType Type, lexeme string, literal interface{}, line, column int) *Token {
+	return &Token{tokenType, lexeme, literal, line, column}
 }
 
 func (token *Token) String() string {
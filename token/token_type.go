@@ -4,26 +4,46 @@ type Type string
 
 const (
 	// Single character tokens
-	LEFT_PAREN  = "LEFT_PAREN"
-	RIGHT_PAREN = "RIGHT_PAREN"
-	LEFT_BRACE  = "LEFT_BRACE"
-	RIGHT_BRACE = "RIGHT_BRACE"
-	COMMA       = "COMMA"
-	DOT         = "DOT"
-	MINUS       = "MINUS"
-	PLUS        = "PLUS"
-	SEMICOLON   = "SEMICOLON"
-	SLASH       = "SLASH"
-	STAR        = "STAR"
+	LEFT_PAREN    = "LEFT_PAREN"
+	RIGHT_PAREN   = "RIGHT_PAREN"
+	LEFT_BRACE    = "LEFT_BRACE"
+	RIGHT_BRACE   = "RIGHT_BRACE"
+	LEFT_BRACKET  = "LEFT_BRACKET"
+	RIGHT_BRACKET = "RIGHT_BRACKET"
+	COMMA         = "COMMA"
+	DOT           = "DOT"
+	MINUS         = "MINUS"
+	PLUS          = "PLUS"
+	SEMICOLON     = "SEMICOLON"
+	SLASH         = "SLASH"
+	STAR          = "STAR"
+	PERCENT       = "PERCENT"
+	QUESTION      = "QUESTION"
+	COLON         = "COLON"
+	AMPERSAND     = "AMPERSAND"
+	PIPE          = "PIPE"
+	CARET         = "CARET"
+	TILDE         = "TILDE"
 	// One or two character tokens
-	BANG          = "BANG"
-	BANG_EQUAL    = "BANG_EQUAL"
-	EQUAL         = "EQUAL"
-	EQUAL_EQUAL   = "EQUAL_EQUAL"
-	GREATER       = "GREATER"
-	GREATER_EQUAL = "GREATER_EQUAL"
-	LESS          = "LESS"
-	LESS_EQUAL    = "LESS_EQUAL"
+	ARROW           = "ARROW"
+	BANG            = "BANG"
+	BANG_EQUAL      = "BANG_EQUAL"
+	EQUAL           = "EQUAL"
+	EQUAL_EQUAL     = "EQUAL_EQUAL"
+	GREATER         = "GREATER"
+	GREATER_EQUAL   = "GREATER_EQUAL"
+	GREATER_GREATER = "GREATER_GREATER"
+	LESS            = "LESS"
+	LESS_EQUAL      = "LESS_EQUAL"
+	LESS_LESS       = "LESS_LESS"
+	MINUS_EQUAL     = "MINUS_EQUAL"
+	MINUS_MINUS     = "MINUS_MINUS"
+	PLUS_EQUAL      = "PLUS_EQUAL"
+	PLUS_PLUS       = "PLUS_PLUS"
+	SLASH_EQUAL     = "SLASH_EQUAL"
+	STAR_EQUAL      = "STAR_EQUAL"
+	STAR_STAR       = "STAR_STAR"
+	TILDE_SLASH     = "TILDE_SLASH"
 	// Literals
 	IDENTIFIER = "IDENTIFIER"
 	STRING     = "STRING"
@@ -36,6 +56,7 @@ const (
 	FUN    = "FUN"
 	FOR    = "FOR"
 	IF     = "IF"
+	IN     = "IN"
 	NIL    = "NIL"
 	OR     = "OR"
 	PRINT  = "PRINT"
@@ -45,6 +66,7 @@ const (
 	TRUE   = "TRUE"
 	VAR    = "VAR"
 	WHILE  = "WHILE"
+	YIELD  = "YIELD"
 	// End of file
 	EOF = "EOF"
 )
@@ -57,6 +79,7 @@ var Keywords = map[string]Type{
 	"for":    FOR,
 	"fun":    FUN,
 	"if":     IF,
+	"in":     IN,
 	"nil":    NIL,
 	"or":     OR,
 	"print":  PRINT,
@@ -66,4 +89,5 @@ var Keywords = map[string]Type{
 	"true":   TRUE,
 	"var":    VAR,
 	"while":  WHILE,
+	"yield":  YIELD,
 }
@@ -13,22 +13,32 @@ func generateAst() {
 		"Assign   : Name *token.Token, Value Expr",
 		"Binary	  : Left Expr, Operator *token.Token, Right Expr",
 		"Call     : Callee Expr, Paren *token.Token, Arguments []Expr",
+		"Conditional : Condition Expr, ThenBranch Expr, ElseBranch Expr",
+		"Get      : Object Expr, Name *token.Token",
 		"Grouping : Expression Expr",
+		"Index    : Object Expr, Bracket *token.Token, Index Expr",
+		"Lambda   : Keyword *token.Token, Params []*token.Token, Body []Stmt, Generator bool",
+		"List     : Bracket *token.Token, Elements []Expr",
 		"Literal  : Value interface{}",
 		"Logical  : Left Expr, Operator *token.Token, Right Expr",
+		"Map      : Brace *token.Token, Keys []Expr, Values []Expr",
+		"SetIndex : Object Expr, Bracket *token.Token, Index Expr, Value Expr",
 		"Unary    : Operator *token.Token, Right Expr",
+		"Update   : Target Expr, Operator *token.Token, Value Expr, Postfix bool",
 		"Variable : Name *token.Token",
 	})
 
 	defineAst(outputDir, "Stmt", []string{
 		"Block      : Statements []Stmt",
 		"Expression : Expr Expr",
-		"If         : Condition Expr, ThenBranch Stmt, ElseBranch Stmt",
-		"Function   : Name *token.Token, Params []*token.Token, Body []Stmt",
-		"Print      : Expr Expr",
+		"ForIn      : Name *token.Token, Iterable Expr, Body Stmt",
+		"If         : Keyword *token.Token, Condition Expr, ThenBranch Stmt, ElseBranch Stmt",
+		"Function   : Name *token.Token, Params []*token.Token, Body []Stmt, Generator bool",
+		"Print      : Keyword *token.Token, Expr Expr",
 		"Return     : Keyword *token.Token, Value Expr",
 		"Var        : Name *token.Token, Initializer Expr",
-		"While      : Condition Expr, Body Stmt",
+		"While      : Keyword *token.Token, Condition Expr, Body Stmt",
+		"Yield      : Keyword *token.Token, Value Expr",
 	})
 }
 
@@ -0,0 +1,100 @@
+package tracer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/iCiaran/golox/ast"
+	"github.com/iCiaran/golox/interpreter"
+)
+
+type Tracer struct {
+	mu     sync.Mutex
+	out    io.Writer
+	json   bool
+	source []string
+}
+
+type entry struct {
+	Event     string   `json:"event"`
+	Depth     int      `json:"depth"`
+	Line      int      `json:"line,omitempty"`
+	Statement string   `json:"statement,omitempty"`
+	Source    string   `json:"source,omitempty"`
+	Function  string   `json:"function,omitempty"`
+	Arguments []string `json:"arguments,omitempty"`
+	Value     *string  `json:"value,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+func New(source string, out io.Writer, json bool) *Tracer {
+	return &Tracer{out: out, json: json, source: strings.Split(source, "\n")}
+}
+
+func (t *Tracer) Statement(in *interpreter.Interpreter, stmt ast.Stmt, line int) {
+	t.write(&entry{
+		Event:     "statement",
+		Depth:     in.Depth(),
+		Line:      line,
+		Statement: reflect.Indirect(reflect.ValueOf(stmt)).Type().Name(),
+		Source:    t.line(line),
+	})
+}
+
+func (t *Tracer) Call(in *interpreter.Interpreter, function interpreter.Callable, arguments []interface{}) {
+	values := make([]string, len(arguments))
+	for n, argument := range arguments {
+		values[n] = interpreter.Stringify(argument)
+	}
+	t.write(&entry{Event: "call", Depth: in.Depth(), Function: interpreter.Stringify(function), Arguments: values})
+}
+
+func (t *Tracer) Return(in *interpreter.Interpreter, function interpreter.Callable, value interface{}) {
+	s := interpreter.Stringify(value)
+	t.write(&entry{Event: "return", Depth: in.Depth(), Function: interpreter.Stringify(function), Value: &s})
+}
+
+func (t *Tracer) Unwind(in *interpreter.Interpreter, function interpreter.Callable, err error) {
+	t.write(&entry{Event: "unwind", Depth: in.Depth(), Function: interpreter.Stringify(function), Error: err.Error()})
+}
+
+func (t *Tracer) line(n int) string {
+	if n < 1 || n > len(t.source) {
+		return ""
+	}
+	return strings.TrimSpace(t.source[n-1])
+}
+
+func (t *Tracer) write(e *entry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.json {
+		encoder := json.NewEncoder(t.out)
+		encoder.SetEscapeHTML(false)
+		encoder.Encode(e)
+		return
+	}
+
+	switch e.Event {
+	case "statement":
+		fmt.Fprintf(t.out, "%s%d: %s\n", indent(e.Depth), e.Line, e.Source)
+	case "call":
+		fmt.Fprintf(t.out, "%scall %s(%s)\n", indent(e.Depth-1), e.Function, strings.Join(e.Arguments, ", "))
+	case "return":
+		fmt.Fprintf(t.out, "%sreturn %s = %s\n", indent(e.Depth-1), e.Function, *e.Value)
+	case "unwind":
+		fmt.Fprintf(t.out, "%sunwind %s: %s\n", indent(e.Depth-1), e.Function, e.Error)
+	}
+}
+
+func indent(depth int) string {
+	if depth < 0 {
+		depth = 0
+	}
+	return strings.Repeat("  ", depth)
+}
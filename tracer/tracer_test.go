@@ -0,0 +1,126 @@
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/iCiaran/golox/interpreter"
+	"github.com/iCiaran/golox/loxerror"
+	"github.com/iCiaran/golox/parser"
+	"github.com/iCiaran/golox/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+const script = `fun add(a, b) {
+  var sum = a + b;
+  return sum;
+}
+var total = add(1, add(2, 3));`
+
+func TestTracer(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		json bool
+		want string
+	}{
+		{
+			json: false,
+			want: `1: fun add(a, b) {
+5: var total = add(1, add(2, 3));
+call <fn add>(2, 3)
+  2: var sum = a + b;
+  3: return sum;
+return <fn add> = 5
+call <fn add>(1, 5)
+  2: var sum = a + b;
+  3: return sum;
+return <fn add> = 6
+`,
+		},
+		{
+			json: true,
+			want: `{"event":"statement","depth":0,"line":1,"statement":"Function","source":"fun add(a, b) {"}
+{"event":"statement","depth":0,"line":5,"statement":"Var","source":"var total = add(1, add(2, 3));"}
+{"event":"call","depth":1,"function":"<fn add>","arguments":["2","3"]}
+{"event":"statement","depth":1,"line":2,"statement":"Var","source":"var sum = a + b;"}
+{"event":"statement","depth":1,"line":3,"statement":"Return","source":"return sum;"}
+{"event":"return","depth":1,"function":"<fn add>","value":"5"}
+{"event":"call","depth":1,"function":"<fn add>","arguments":["1","5"]}
+{"event":"statement","depth":1,"line":2,"statement":"Var","source":"var sum = a + b;"}
+{"event":"statement","depth":1,"line":3,"statement":"Return","source":"return sum;"}
+{"event":"return","depth":1,"function":"<fn add>","value":"6"}
+`,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			reporter := loxerror.NewReporter(ioutil.Discard)
+			statements := parser.NewParser(scanner.New(script, reporter).ScanTokens(), reporter).Parse()
+
+			var out bytes.Buffer
+			in := interpreter.NewInterpreter()
+			in.SetTracer(New(script, &out, test.json))
+			assert.Nil(in.InterpretContext(context.Background(), statements))
+			assert.Equal(test.want, out.String())
+		})
+	}
+}
+
+const failing = `fun f() { return nope; }
+fun g() { f(); }
+g();`
+
+func TestTracerUnwind(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		json bool
+		want string
+	}{
+		{
+			json: false,
+			want: `1: fun f() { return nope; }
+2: fun g() { f(); }
+3: g();
+call <fn g>()
+  2: fun g() { f(); }
+  call <fn f>()
+    1: fun f() { return nope; }
+  unwind <fn f>: Undefined variable 'nope'.
+unwind <fn g>: Undefined variable 'nope'.
+`,
+		},
+		{
+			json: true,
+			want: `{"event":"statement","depth":0,"line":1,"statement":"Function","source":"fun f() { return nope; }"}
+{"event":"statement","depth":0,"line":2,"statement":"Function","source":"fun g() { f(); }"}
+{"event":"statement","depth":0,"line":3,"statement":"Expression","source":"g();"}
+{"event":"call","depth":1,"function":"<fn g>"}
+{"event":"statement","depth":1,"line":2,"statement":"Expression","source":"fun g() { f(); }"}
+{"event":"call","depth":2,"function":"<fn f>"}
+{"event":"statement","depth":2,"line":1,"statement":"Return","source":"fun f() { return nope; }"}
+{"event":"unwind","depth":2,"function":"<fn f>","error":"Undefined variable 'nope'."}
+{"event":"unwind","depth":1,"function":"<fn g>","error":"Undefined variable 'nope'."}
+`,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint("test_", i), func(t *testing.T) {
+			reporter := loxerror.NewReporter(ioutil.Discard)
+			statements := parser.NewParser(scanner.New(failing, reporter).ScanTokens(), reporter).Parse()
+
+			var out bytes.Buffer
+			in := interpreter.NewInterpreter()
+			in.SetErrorOutput(ioutil.Discard)
+			in.SetTracer(New(failing, &out, test.json))
+			assert.IsType(&loxerror.Runtime{}, in.InterpretContext(context.Background(), statements))
+			assert.Equal(test.want, out.String())
+		})
+	}
+}